@@ -33,6 +33,7 @@ func newServer() *handler.Server {
 	})
 	opts := handler.NewServerOptions{
 		Repository: repo,
+		Hub:        handler.NewMissionHub(),
 	}
 	return handler.NewServer(opts)
 }
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/naufalfmm/plantation-drone-api/simulator"
+)
+
+func main() {
+	cfg := simulator.DefaultConfig()
+	opts := simulator.RunOptions{}
+
+	apiUrl := flag.String("api", "http://localhost:1323", "the base URL of the API")
+	missionId := flag.String("mission", "", "the id of the mission to fly")
+	flag.Float64Var(&cfg.Speed, "speed", cfg.Speed, "the flying speed, in metres per second")
+	flag.Float64Var(&cfg.BatteryPerMeter, "battery-per-meter", cfg.BatteryPerMeter, "the battery used for every metre flown, in percent")
+	seed := flag.Int64("seed", 0, "the seed of the GPS drift, or 0 for a random one")
+	flag.Float64Var(&cfg.Faults.BatteryDrain, "drain", 1, "multiply the battery used for every metre, aborting once it runs out")
+	flag.Float64Var(&cfg.Faults.GPSDrift, "drift", 0, "the chance of reporting a point over a neighbouring plot")
+	flag.Float64Var(&cfg.Faults.AbortAfter, "abort-after", 0, "abort after this share of the plan distance, from 0 to 1")
+	flag.IntVar(&opts.BatchSize, "batch", 10, "the number of points in a telemetry batch")
+	flag.Float64Var(&opts.Speedup, "speedup", 1, "play the flight this many times faster, or 0 to post it right away")
+	flag.Parse()
+
+	if *missionId == "" || cfg.Speed <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *seed != 0 {
+		cfg.Seed = *seed
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result, err := simulator.Run(ctx, simulator.NewClient(*apiUrl), *missionId, cfg, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("mission %s %s: %d points sent, %d stored\n", *missionId, result.Status, len(result.Flight.Points), result.Stored)
+	if result.Flight.AbortReason != "" {
+		fmt.Printf("aborted: %s\n", result.Flight.AbortReason)
+	}
+}
@@ -14,24 +14,176 @@ CREATE TABLE IF NOT EXISTS estates (
     max BIGINT NOT NULL DEFAULT 0,
     min BIGINT NOT NULL DEFAULT 0,
     drone_distance BIGINT NOT NULL DEFAULT 0,
-    median DOUBLE PRECISION NOT NULL DEFAULT 0,
+    median DOUBLE PRECISION,
+    tree_version BIGINT NOT NULL DEFAULT 0,
+    ceiling BIGINT NOT NULL DEFAULT 120,
+    home_x BIGINT,
+    home_y BIGINT,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     
     PRIMARY KEY(id)
 );
 
+CREATE TABLE IF NOT EXISTS tree_species (
+    id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    max_height BIGINT NOT NULL,
+    growth_profile VARCHAR(16) NOT NULL DEFAULT 'moderate',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY(id),
+    UNIQUE (name)
+);
+
 CREATE TABLE IF NOT EXISTS estate_trees (
     id VARCHAR(36) NOT NULL,
     estate_id VARCHAR(36) NOT NULL,
+    species_id VARCHAR(36),
     x BIGINT NOT NULL,
     y BIGINT NOT NULL,
     height BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'planted',
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     
     PRIMARY KEY(id),
-    FOREIGN KEY (estate_id) REFERENCES estates(id)
+    FOREIGN KEY (estate_id) REFERENCES estates(id),
+    FOREIGN KEY (species_id) REFERENCES tree_species(id)
 );
 
 CREATE INDEX IF NOT EXISTS idx_estate_estate_trees ON estate_trees(estate_id);
+
+
+CREATE TABLE IF NOT EXISTS tree_status_history (
+    id BIGSERIAL NOT NULL,
+    tree_id VARCHAR(36) NOT NULL,
+    from_status VARCHAR(16),
+    to_status VARCHAR(16) NOT NULL,
+    reason VARCHAR(255),
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY(id),
+    FOREIGN KEY (tree_id) REFERENCES estate_trees(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tree_tree_status_history ON tree_status_history(tree_id);
+
+
+CREATE TABLE IF NOT EXISTS estate_obstacles (
+    id VARCHAR(36) NOT NULL,
+    estate_id VARCHAR(36) NOT NULL,
+    x BIGINT NOT NULL,
+    y BIGINT NOT NULL,
+    height BIGINT NOT NULL DEFAULT 0,
+    no_fly BOOLEAN NOT NULL DEFAULT FALSE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY(id),
+    FOREIGN KEY (estate_id) REFERENCES estates(id),
+    UNIQUE (estate_id, x, y)
+);
+
+CREATE TABLE IF NOT EXISTS estate_elevations (
+    estate_id VARCHAR(36) NOT NULL,
+    x BIGINT NOT NULL,
+    y BIGINT NOT NULL,
+    elevation BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY(estate_id, x, y),
+    FOREIGN KEY (estate_id) REFERENCES estates(id)
+);
+
+
+CREATE TABLE IF NOT EXISTS drones (
+    id VARCHAR(36) NOT NULL,
+    model VARCHAR(255) NOT NULL,
+    max_range BIGINT NOT NULL,
+    max_altitude BIGINT NOT NULL,
+    cruise_speed DOUBLE PRECISION NOT NULL,
+    climb_rate DOUBLE PRECISION NOT NULL,
+    battery_capacity DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY(id)
+);
+
+
+CREATE TABLE IF NOT EXISTS drone_plan_snapshots (
+    id VARCHAR(36) NOT NULL,
+    estate_id VARCHAR(36) NOT NULL,
+    version BIGINT NOT NULL,
+    mode VARCHAR(32) NOT NULL,
+    tree_hash VARCHAR(64) NOT NULL,
+    distance BIGINT NOT NULL,
+    flight_time DOUBLE PRECISION NOT NULL,
+    energy DOUBLE PRECISION NOT NULL,
+    waypoints JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY(id),
+    FOREIGN KEY (estate_id) REFERENCES estates(id),
+    UNIQUE(estate_id, version)
+);
+
+
+CREATE TABLE IF NOT EXISTS missions (
+    id VARCHAR(36) NOT NULL,
+    estate_id VARCHAR(36) NOT NULL,
+    snapshot_id VARCHAR(36) NOT NULL,
+    drone_id VARCHAR(36),
+    status VARCHAR(16) NOT NULL DEFAULT 'scheduled',
+    abort_reason VARCHAR(255),
+    started_at TIMESTAMP WITH TIME ZONE,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY(id),
+    FOREIGN KEY (estate_id) REFERENCES estates(id),
+    FOREIGN KEY (snapshot_id) REFERENCES drone_plan_snapshots(id),
+    FOREIGN KEY (drone_id) REFERENCES drones(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_estate_missions ON missions(estate_id);
+
+
+CREATE TABLE IF NOT EXISTS mission_telemetry (
+    mission_id VARCHAR(36) NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    x BIGINT NOT NULL,
+    y BIGINT NOT NULL,
+    altitude DOUBLE PRECISION NOT NULL,
+    battery DOUBLE PRECISION NOT NULL,
+
+    PRIMARY KEY(mission_id, recorded_at),
+    FOREIGN KEY (mission_id) REFERENCES missions(id)
+);
+
+
+CREATE TABLE IF NOT EXISTS tree_observations (
+    id VARCHAR(36) NOT NULL,
+    tree_id VARCHAR(36) NOT NULL,
+    mission_id VARCHAR(36),
+    health_score BIGINT NOT NULL,
+    suspected_disease VARCHAR(255),
+    pests TEXT[] NOT NULL DEFAULT '{}',
+    fruit_count BIGINT,
+    notes TEXT,
+    observed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY(id),
+    FOREIGN KEY (tree_id) REFERENCES estate_trees(id),
+    FOREIGN KEY (mission_id) REFERENCES missions(id),
+    CHECK (health_score BETWEEN 1 AND 10)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tree_tree_observations ON tree_observations(tree_id, observed_at);
+CREATE INDEX IF NOT EXISTS idx_mission_tree_observations ON tree_observations(mission_id);
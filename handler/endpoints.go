@@ -3,8 +3,8 @@ package handler
 import (
 	"database/sql"
 	"fmt"
-	"math"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -41,11 +41,41 @@ func (s *Server) PostEstate(ctx echo.Context) error {
 		})
 	}
 
+	ceiling := defaultCeiling
+	if req.Ceiling != nil {
+		if *req.Ceiling <= 0 {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrNegativeZeroBuilder("ceiling").Error(),
+			})
+		}
+
+		ceiling = *req.Ceiling
+	}
+
+	var elevations []repository.PlotElevation
+	if req.Elevations != nil {
+		var err error
+		elevations, err = buildElevations(*req.Elevations, req.Length, req.Width)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+	}
+
+	// The drone climbs and descends with the terrain even above the empty plots
+	grid := buildHeightGrid(nil, req.Length, req.Width)
+	elevationDistance := flightDistance(buildFlightPath(newAirspace(grid, nil, elevations))) - flightDistance(buildFlightPath(newAirspace(grid, nil, nil)))
+
 	id := uuid.New().String()
 	err := s.Repository.CreateEstate(ctx.Request().Context(), repository.CreateEstateInput{
-		Id:     id,
-		Width:  req.Width,
-		Length: req.Length,
+		Id:      id,
+		Width:   req.Width,
+		Length:  req.Length,
+		Ceiling: ceiling,
+
+		Elevations:        elevations,
+		ElevationDistance: elevationDistance,
 	})
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
@@ -80,7 +110,38 @@ func (s *Server) PostEstateIdTree(ctx echo.Context, id string) error {
 		})
 	}
 
-	if req.Height <= 0 || req.Height > 30 {
+	if req.Height <= 0 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrHeightOutOfRange.Error(),
+		})
+	}
+
+	// A tree of a species grows as tall as the species does
+	var speciesId sql.NullString
+	if req.SpeciesId != nil {
+		species, err := s.Repository.GetSpeciesById(ctx.Request().Context(), repository.GetSpeciesByIdInput{
+			Id: *req.SpeciesId,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+					Message: ErrNotFoundBuilder("species").Error(),
+				})
+			}
+
+			return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+
+		if req.Height > species.MaxHeight {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrSpeciesHeightBuilder(species.Name, species.MaxHeight).Error(),
+			})
+		}
+
+		speciesId = sql.NullString{String: species.Id, Valid: true}
+	} else if req.Height > defaultMaxTreeHeight {
 		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
 			Message: ErrHeightOutOfRange.Error(),
 		})
@@ -107,9 +168,17 @@ func (s *Server) PostEstateIdTree(ctx echo.Context, id string) error {
 		})
 	}
 
+	// The drone follows the canopy, so it climbs above the ground as high as
+	// the tree and its clearance
+	tree := flightWaypoint{X: req.X, Y: req.Y, Altitude: req.Height + droneClearance}
+	if violations := ceilingViolations([]flightWaypoint{tree}, est.Ceiling); len(violations) > 0 {
+		return ctx.JSON(http.StatusBadRequest, buildCeilingErrorResponse(est.Ceiling, violations))
+	}
+
 	c, err := s.Repository.CountCoordinateTree(ctx.Request().Context(), repository.CountCoordinateTreeInput{
-		X: req.X,
-		Y: req.Y,
+		EstateId: id,
+		X:        req.X,
+		Y:        req.Y,
 	})
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
@@ -124,11 +193,14 @@ func (s *Server) PostEstateIdTree(ctx echo.Context, id string) error {
 
 	prevX, prevY, nextX, nextY := getPrevNextCoordinate(req.X, req.Y, est.Length)
 
-	prevNextHeights, err := s.Repository.GetPrevNextTree(ctx.Request().Context(), repository.GetPrevNextTreeInput{
-		PrevX: prevX,
-		PrevY: prevY,
-		NextX: nextX,
-		NextY: nextY,
+	prevNext, err := s.Repository.GetPrevNextTree(ctx.Request().Context(), repository.GetPrevNextTreeInput{
+		EstateId: id,
+		PrevX:    prevX,
+		PrevY:    prevY,
+		X:        req.X,
+		Y:        req.Y,
+		NextX:    nextX,
+		NextY:    nextY,
 	})
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
@@ -136,15 +208,26 @@ func (s *Server) PostEstateIdTree(ctx echo.Context, id string) error {
 		})
 	}
 
+	// The drone takes off and lands on the first and the last plot, which is
+	// the same as flying from or to a plot on the same ground without tree
+	prevElevation, nextElevation := prevNext.PrevElevation, prevNext.NextElevation
+	if prevY < 1 {
+		prevElevation = prevNext.Elevation
+	}
+	if nextY > est.Width {
+		nextElevation = prevNext.Elevation
+	}
+
 	treeId := uuid.New().String()
 	err = s.Repository.CreateTree(ctx.Request().Context(), repository.CreateTreeInput{
-		Id:     treeId,
-		X:      req.X,
-		Y:      req.Y,
-		Height: req.Height,
+		Id:        treeId,
+		X:         req.X,
+		Y:         req.Y,
+		Height:    req.Height,
+		SpeciesId: speciesId,
 
 		EstateId:        id,
-		DroneDistFactor: int(math.Abs(float64(req.Height)-float64(prevNextHeights.PrevTreeHeight)) + math.Abs(float64(req.Height)-float64(prevNextHeights.NextTreeHeight)) - float64(prevNextHeights.PrevTreeHeight) - float64(prevNextHeights.NextTreeHeight)),
+		DroneDistFactor: droneDistFactor(req.Height, prevNext.Elevation, prevElevation+prevNext.PrevTreeHeight+droneClearance, nextElevation+prevNext.NextTreeHeight+droneClearance),
 	})
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
@@ -159,7 +242,13 @@ func (s *Server) PostEstateIdTree(ctx echo.Context, id string) error {
 
 // The endpoint of retrieving the estate stats, that are max, min, count, and median of trees
 // (GET /estate/{id}/stats)
-func (s *Server) GetEstateIdStats(ctx echo.Context, id string) error {
+func (s *Server) GetEstateIdStats(ctx echo.Context, id string, params generated.GetEstateIdStatsParams) error {
+	if params.GroupBy != nil && *params.GroupBy != statGroupByRow && *params.GroupBy != statGroupByColumn && *params.GroupBy != statGroupBySpecies {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrInvalidGroupBy.Error(),
+		})
+	}
+
 	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
 		Id: id,
 	})
@@ -175,8 +264,12 @@ func (s *Server) GetEstateIdStats(ctx echo.Context, id string) error {
 		})
 	}
 
-	median := est.Median
-	if median == 0 {
+	if isRegionalStats(params) {
+		return s.getEstateRegionStats(ctx, est, params)
+	}
+
+	median := est.Median.Float64
+	if !est.Median.Valid {
 		treeHeights, err := s.Repository.GetHeightEstateTrees(ctx.Request().Context(), repository.GetHeightEstateTreesInput{
 			EstateId: id,
 		})
@@ -188,10 +281,16 @@ func (s *Server) GetEstateIdStats(ctx echo.Context, id string) error {
 
 		median = findMedian(treeHeights.Heights)
 
-		s.Repository.StoreMedianEstate(ctx.Request().Context(), repository.StoreMedianEstateInput{
-			EstateId: id,
-			Median:   median,
+		err = s.Repository.StoreMedianEstate(ctx.Request().Context(), repository.StoreMedianEstateInput{
+			EstateId:    id,
+			Median:      median,
+			TreeVersion: est.TreeVersion,
 		})
+		if err != nil {
+			return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
 	}
 
 	return ctx.JSON(http.StatusOK, generated.EstateStatResponse{
@@ -202,9 +301,379 @@ func (s *Server) GetEstateIdStats(ctx echo.Context, id string) error {
 	})
 }
 
+// getEstateRegionStats computes the stats of the trees inside the requested
+// region. Unlike the whole-estate stats, these are never cached.
+func (s *Server) getEstateRegionStats(ctx echo.Context, est repository.GetEstateByIdOutput, params generated.GetEstateIdStatsParams) error {
+	region, err := buildStatRegion(params, est.Length, est.Width)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+		EstateId: est.Id,
+		MinX:     region.minX,
+		MaxX:     region.maxX,
+		MinY:     region.minY,
+		MaxY:     region.maxY,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	heights := make([]int, len(trees.Trees))
+	for i, tree := range trees.Trees {
+		heights[i] = tree.Height
+	}
+
+	var resp generated.EstateStatResponse
+	resp.Count, resp.Max, resp.Min, resp.Median = buildStat(heights)
+
+	if params.GroupBy != nil && *params.GroupBy == statGroupBySpecies {
+		catalogue, err := s.Repository.GetAllSpecies(ctx.Request().Context(), repository.GetAllSpeciesInput{})
+		if err != nil {
+			return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+
+		groups := buildSpeciesStatGroups(trees.Trees, catalogue.Species)
+		resp.SpeciesGroups = &groups
+	} else if params.GroupBy != nil {
+		groups := buildStatGroups(trees.Trees, *params.GroupBy, region)
+		resp.Groups = &groups
+	}
+
+	if params.Coverage != nil && *params.Coverage {
+		grid := buildHeightGrid(trees.Trees, est.Length, est.Width)
+		coverage := buildCoverage(grid, region, buildFlightPath(newAirspace(grid, nil, nil)))
+		resp.Coverage = &coverage
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
+
 // The endpoint of retrieving the estate drone plan
 // (GET /estate/{id}/drone-plan)
-func (s *Server) GetEstateIdDronePlan(ctx echo.Context, id string) error {
+func (s *Server) GetEstateIdDronePlan(ctx echo.Context, id string, params generated.GetEstateIdDronePlanParams) error {
+	mode, swath, err := validateFlightMode(params.Mode, params.Swath)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	var limit flightLimit
+	if params.MaxDistance != nil {
+		if *params.MaxDistance <= 0 {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrNegativeZeroBuilder("max_distance").Error(),
+			})
+		}
+
+		limit.maxDistance = *params.MaxDistance
+	}
+
+	if params.MaxEnergy != nil {
+		if *params.MaxEnergy <= 0 {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrNegativeZeroBuilder("max_energy").Error(),
+			})
+		}
+
+		limit.maxEnergy = *params.MaxEnergy
+	}
+
+	if params.Reserve != nil && (*params.Reserve < 0 || *params.Reserve >= 1) {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrInvalidReserve.Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	obstacles, err := s.Repository.GetEstateObstacles(ctx.Request().Context(), repository.GetEstateObstaclesInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	elevations, err := s.Repository.GetEstateElevations(ctx.Request().Context(), repository.GetEstateElevationsInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	profile, ceiling := defaultDroneProfile, est.Ceiling
+	if params.Drone != nil {
+		drone, err := s.Repository.GetDroneById(ctx.Request().Context(), repository.GetDroneByIdInput{
+			Id: *params.Drone,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+					Message: ErrNotFoundBuilder("drone").Error(),
+				})
+			}
+
+			return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+
+		profile, limit = withDrone(profile, limit, drone.Drone)
+		if ceiling == 0 || drone.MaxAltitude < ceiling {
+			ceiling = drone.MaxAltitude
+		}
+	}
+
+	if params.Reserve != nil {
+		limit = limit.withReserve(*params.Reserve)
+	}
+
+	// The stored distance only knows about the trees and the terrain, so the
+	// path is walked again once the drone has to climb over or detour around
+	// an obstacle, ferries from and back to its home, does not follow the
+	// canopy, has to rest on the way, or may fly above the ceiling
+	canopyClears := ceiling == 0 || est.Max+droneClearance <= ceiling
+	if mode == flightModeCanopyFollowing && len(obstacles.Obstacles) == 0 && !est.HomeX.Valid && limit == (flightLimit{}) && canopyClears {
+		ground := buildElevationGrid(elevations.Elevations, est.Length, est.Width)
+		landingX := 1
+		if est.Width%2 != 0 {
+			landingX = est.Length
+		}
+
+		cost := profile.cost(splitDroneDistance(est.DroneDistance, est.Length, est.Width, ground[0][0], ground[est.Width-1][landingX-1]))
+
+		return ctx.JSON(http.StatusOK, generated.EstateDronePlanResponse{
+			Distance:   est.DroneDistance,
+			FlightTime: cost.time,
+			Energy:     cost.energy,
+			Mode:       mode,
+		})
+	}
+
+	trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+		EstateId: id,
+		MinX:     1,
+		MaxX:     est.Length,
+		MinY:     1,
+		MaxY:     est.Width,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	space := newAirspace(buildHeightGrid(trees.Trees, est.Length, est.Width), obstacles.Obstacles, elevations.Elevations)
+	if est.HomeX.Valid {
+		space = space.withHome(int(est.HomeX.Int64), int(est.HomeY.Int64))
+	}
+
+	canopyPath := buildFlightPath(space)
+	canopyCost := profile.cost(flightLegs(canopyPath))
+
+	path, cost := canopyPath, canopyCost
+	var comparison *generated.EstateFlightModeComparison
+	if mode != flightModeCanopyFollowing {
+		constantSpace := space.atConstantAltitude(est.Max)
+		constantPath := buildFlightPath(constantSpace)
+		constantCost := profile.cost(flightLegs(constantPath))
+
+		comparison = &generated.EstateFlightModeComparison{
+			CanopyFollowing:  buildFlightModeEstimate(canopyCost),
+			ConstantAltitude: buildFlightModeEstimate(constantCost),
+		}
+
+		switch mode {
+		case flightModeConstantAltitude:
+			space, path, cost = constantSpace, constantPath, constantCost
+		case flightModeCoverage:
+			space = space.withSwath(swath)
+			path = buildFlightPath(space)
+			cost = profile.cost(flightLegs(path))
+
+			coverage := buildFlightModeEstimate(cost)
+			comparison.Coverage = &coverage
+		}
+	}
+
+	if violations := ceilingViolations(path, ceiling); len(violations) > 0 {
+		return ctx.JSON(http.StatusBadRequest, buildCeilingErrorResponse(ceiling, violations))
+	}
+
+	resp := generated.EstateDronePlanResponse{
+		Distance:   cost.distance,
+		FlightTime: cost.time,
+		Energy:     cost.energy,
+		Mode:       mode,
+		Comparison: comparison,
+	}
+
+	if i, ok := findRestPoint(path, limit, profile); ok {
+		restCost := profile.cost(flightLegs(path[:i+1]))
+		resp.Rest = &generated.EstateRestPointResponse{
+			X:          path[i].X,
+			Y:          path[i].Y,
+			Distance:   restCost.distance,
+			FlightTime: restCost.time,
+			Energy:     restCost.energy,
+		}
+
+		// The drone may still rest on the way when it cannot go back home
+		// to swap its battery
+		if sorties, ok := planSorties(space, limit, profile); ok {
+			resp.Sorties = buildSortieResponses(sorties)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// The endpoint of freezing the estate drone plan as its next version
+// (POST /estate/{id}/drone-plan:snapshot)
+func (s *Server) PostEstateIdDronePlanSnapshot(ctx echo.Context, id string) error {
+	var req generated.DronePlanSnapshotRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	mode, swath, err := validateFlightMode(req.Mode, req.Swath)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+		EstateId: id,
+		MinX:     1,
+		MaxX:     est.Length,
+		MinY:     1,
+		MaxY:     est.Width,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	obstacles, err := s.Repository.GetEstateObstacles(ctx.Request().Context(), repository.GetEstateObstaclesInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	elevations, err := s.Repository.GetEstateElevations(ctx.Request().Context(), repository.GetEstateElevationsInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	space := newAirspace(buildHeightGrid(trees.Trees, est.Length, est.Width), obstacles.Obstacles, elevations.Elevations)
+	if est.HomeX.Valid {
+		space = space.withHome(int(est.HomeX.Int64), int(est.HomeY.Int64))
+	}
+
+	switch mode {
+	case flightModeConstantAltitude:
+		space = space.atConstantAltitude(est.Max)
+	case flightModeCoverage:
+		space = space.withSwath(swath)
+	}
+
+	path := buildFlightPath(space)
+	if violations := ceilingViolations(path, est.Ceiling); len(violations) > 0 {
+		return ctx.JSON(http.StatusBadRequest, buildCeilingErrorResponse(est.Ceiling, violations))
+	}
+
+	cost := defaultDroneProfile.cost(flightLegs(path))
+	snapshot := repository.DronePlanSnapshot{
+		Id:         uuid.New().String(),
+		EstateId:   id,
+		Mode:       mode,
+		TreeHash:   hashTrees(trees.Trees),
+		Distance:   cost.distance,
+		FlightTime: cost.time,
+		Energy:     cost.energy,
+		Waypoints:  buildPlanWaypoints(path),
+	}
+
+	created, err := s.Repository.CreateDronePlanSnapshot(ctx.Request().Context(), repository.CreateDronePlanSnapshotInput{
+		DronePlanSnapshot: snapshot,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	snapshot.Version, snapshot.CreatedAt = created.Version, created.CreatedAt
+
+	return ctx.JSON(http.StatusCreated, buildSnapshotResponse(snapshot, snapshot.TreeHash))
+}
+
+// The endpoint of retrieving a frozen estate drone plan, the latest version
+// by default, and whether the trees changed since
+// (GET /estate/{id}/drone-plan:snapshot)
+func (s *Server) GetEstateIdDronePlanSnapshot(ctx echo.Context, id string, params generated.GetEstateIdDronePlanSnapshotParams) error {
+	var version int
+	if params.Version != nil {
+		if *params.Version <= 0 {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrNegativeZeroBuilder("version").Error(),
+			})
+		}
+
+		version = *params.Version
+	}
+
 	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
 		Id: id,
 	})
@@ -220,7 +689,2116 @@ func (s *Server) GetEstateIdDronePlan(ctx echo.Context, id string) error {
 		})
 	}
 
-	return ctx.JSON(http.StatusOK, generated.EstateDronePlanResponse{
-		Distance: est.DroneDistance,
+	snapshot, err := s.Repository.GetDronePlanSnapshot(ctx.Request().Context(), repository.GetDronePlanSnapshotInput{
+		EstateId: id,
+		Version:  version,
 	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("snapshot").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+		EstateId: id,
+		MinX:     1,
+		MaxX:     est.Length,
+		MinY:     1,
+		MaxY:     est.Width,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, buildSnapshotResponse(snapshot.DronePlanSnapshot, hashTrees(trees.Trees)))
+}
+
+// The endpoint of planning a route above the selected trees only
+// (POST /estate/{id}/inspection-plan)
+func (s *Server) PostEstateIdInspectionPlan(ctx echo.Context, id string) error {
+	var req generated.InspectionPlanRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if req.TreeIds == nil && req.MinHeight == nil && req.MaxHeight == nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrInspectionEmpty.Error(),
+		})
+	}
+
+	if req.MinHeight != nil && req.MaxHeight != nil && *req.MinHeight > *req.MaxHeight {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrInvalidHeightFilter.Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+		EstateId: id,
+		MinX:     1,
+		MaxX:     est.Length,
+		MinY:     1,
+		MaxY:     est.Width,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	selected, err := selectInspectionTrees(trees.Trees, req)
+	if err != nil {
+		return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if len(selected) == 0 {
+		return ctx.JSON(http.StatusOK, generated.InspectionPlanResponse{
+			Unreachable: []string{},
+			Waypoints:   []generated.InspectionWaypointResponse{},
+		})
+	}
+
+	obstacles, err := s.Repository.GetEstateObstacles(ctx.Request().Context(), repository.GetEstateObstaclesInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	elevations, err := s.Repository.GetEstateElevations(ctx.Request().Context(), repository.GetEstateElevationsInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	space := newAirspace(buildHeightGrid(trees.Trees, est.Length, est.Width), obstacles.Obstacles, elevations.Elevations)
+
+	treeIds := make(map[[2]int]string, len(selected))
+	plots := make([][2]int, 0, len(selected))
+	for _, tree := range selected {
+		treeIds[[2]int{tree.X, tree.Y}] = tree.Id
+		plots = append(plots, [2]int{tree.X, tree.Y})
+	}
+
+	// Without a home the drone takes off above the first selected tree it can
+	// fly over, so the route starts there instead of at a plot it never visits
+	var start [2]int
+	if est.HomeX.Valid {
+		start = [2]int{int(est.HomeX.Int64), int(est.HomeY.Int64)}
+		space = space.withHome(start[0], start[1])
+	} else {
+		for _, plot := range plots {
+			if space.flyable(plot[0], plot[1]) {
+				start = plot
+				break
+			}
+		}
+	}
+
+	reached, unreached := space.reachable(start, plots)
+
+	unreachable := make([]string, 0, len(unreached))
+	for _, plot := range unreached {
+		unreachable = append(unreachable, treeIds[plot])
+	}
+
+	if len(reached) == 0 {
+		return ctx.JSON(http.StatusOK, generated.InspectionPlanResponse{
+			Unreachable: unreachable,
+			Waypoints:   []generated.InspectionWaypointResponse{},
+		})
+	}
+
+	var stops [][2]int
+	if est.HomeX.Valid {
+		stops = space.orderInspection(start, reached, true)
+	} else {
+		rest := make([][2]int, 0, len(reached))
+		for _, plot := range reached {
+			if plot != start {
+				rest = append(rest, plot)
+			}
+		}
+
+		stops = append([][2]int{start}, space.orderInspection(start, rest, false)...)
+	}
+
+	path := buildFlightPath(space.visiting(stops))
+	if violations := ceilingViolations(path, est.Ceiling); len(violations) > 0 {
+		return ctx.JSON(http.StatusBadRequest, buildCeilingErrorResponse(est.Ceiling, violations))
+	}
+
+	cost := defaultDroneProfile.cost(flightLegs(path))
+
+	return ctx.JSON(http.StatusOK, generated.InspectionPlanResponse{
+		Distance:    cost.distance,
+		FlightTime:  cost.time,
+		Energy:      cost.energy,
+		Unreachable: unreachable,
+		Waypoints:   buildInspectionWaypoints(path, treeIds),
+	})
+}
+
+// The endpoint of planning a spray mission, dosing every tree by its height
+// and refilling the tank at home on the way
+// (POST /estate/{id}/spray-plan)
+func (s *Server) PostEstateIdSprayPlan(ctx echo.Context, id string) error {
+	var req generated.SprayPlanRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if req.Rate <= 0 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrNegativeZeroBuilder("rate").Error(),
+		})
+	}
+
+	if req.TankCapacity <= 0 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrNegativeZeroBuilder("tank_capacity").Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+		EstateId: id,
+		MinX:     1,
+		MaxX:     est.Length,
+		MinY:     1,
+		MaxY:     est.Width,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	obstacles, err := s.Repository.GetEstateObstacles(ctx.Request().Context(), repository.GetEstateObstaclesInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	elevations, err := s.Repository.GetEstateElevations(ctx.Request().Context(), repository.GetEstateElevationsInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	space := newAirspace(buildHeightGrid(trees.Trees, est.Length, est.Width), obstacles.Obstacles, elevations.Elevations)
+	if est.HomeX.Valid {
+		space = space.withHome(int(est.HomeX.Int64), int(est.HomeY.Int64))
+	}
+
+	path := buildFlightPath(space)
+	if violations := ceilingViolations(path, est.Ceiling); len(violations) > 0 {
+		return ctx.JSON(http.StatusBadRequest, buildCeilingErrorResponse(est.Ceiling, violations))
+	}
+
+	doses := make(map[[2]int]float64, len(trees.Trees))
+	for _, tree := range trees.Trees {
+		doses[[2]int{tree.X, tree.Y}] = float64(tree.Height) * req.Rate
+	}
+
+	mission, ok := planSpray(space, path, doses, req.TankCapacity)
+	if !ok {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrDoseOverTank.Error(),
+		})
+	}
+
+	cost := defaultDroneProfile.cost(mission.legs.horizontal, mission.legs.climb, mission.legs.descent)
+	refills := make([]generated.SprayRefillResponse, 0, len(mission.refills))
+	for _, refill := range mission.refills {
+		refills = append(refills, generated.SprayRefillResponse{
+			X:       refill.at.X,
+			Y:       refill.at.Y,
+			Sprayed: refill.payload,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, generated.SprayPlanResponse{
+		Chemical:    mission.payload,
+		Distance:    cost.distance,
+		FlightTime:  cost.time,
+		Energy:      cost.energy,
+		Refills:     refills,
+		Unreachable: buildPlotResponses(mission.unreachable),
+	})
+}
+
+// The endpoint of planning a seeding mission over the empty plots and
+// refilling the seeds at home on the way
+// (POST /estate/{id}/seeding-plan)
+func (s *Server) PostEstateIdSeedingPlan(ctx echo.Context, id string) error {
+	var req generated.SeedingPlanRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if req.SeedCapacity <= 0 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrNegativeZeroBuilder("seed_capacity").Error(),
+		})
+	}
+
+	seeds := 1
+	if req.SeedsPerPlot != nil {
+		seeds = *req.SeedsPerPlot
+	}
+
+	if seeds <= 0 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrNegativeZeroBuilder("seeds_per_plot").Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+		EstateId: id,
+		MinX:     1,
+		MaxX:     est.Length,
+		MinY:     1,
+		MaxY:     est.Width,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	obstacles, err := s.Repository.GetEstateObstacles(ctx.Request().Context(), repository.GetEstateObstaclesInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	elevations, err := s.Repository.GetEstateElevations(ctx.Request().Context(), repository.GetEstateElevationsInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	space := newAirspace(buildHeightGrid(trees.Trees, est.Length, est.Width), obstacles.Obstacles, elevations.Elevations)
+	if est.HomeX.Valid {
+		space = space.withHome(int(est.HomeX.Int64), int(est.HomeY.Int64))
+	}
+
+	plots := space.emptyPlots()
+	if len(plots) == 0 {
+		return ctx.JSON(http.StatusOK, generated.SeedingPlanResponse{
+			Refills:     []generated.SeedingRefillResponse{},
+			Unreachable: []generated.PlotResponse{},
+			Waypoints:   []generated.SeedingWaypointResponse{},
+		})
+	}
+
+	path := buildFlightPath(space.visiting(plots))
+	if violations := ceilingViolations(path, est.Ceiling); len(violations) > 0 {
+		return ctx.JSON(http.StatusBadRequest, buildCeilingErrorResponse(est.Ceiling, violations))
+	}
+
+	mission, ok := planSeeding(space, path, seeds, req.SeedCapacity)
+	if !ok {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrSeedsOverCapacity.Error(),
+		})
+	}
+
+	cost := defaultDroneProfile.cost(mission.legs.horizontal, mission.legs.climb, mission.legs.descent)
+	refills := make([]generated.SeedingRefillResponse, 0, len(mission.refills))
+	for _, refill := range mission.refills {
+		refills = append(refills, generated.SeedingRefillResponse{
+			X:      refill.at.X,
+			Y:      refill.at.Y,
+			Seeded: refill.plots * seeds,
+		})
+	}
+
+	waypoints := make([]generated.SeedingWaypointResponse, 0, len(path))
+	for _, wp := range path {
+		waypoints = append(waypoints, generated.SeedingWaypointResponse{
+			X:        wp.X,
+			Y:        wp.Y,
+			Altitude: wp.Altitude,
+			Transit:  wp.Transit,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, generated.SeedingPlanResponse{
+		Plots:       mission.plots,
+		Seeds:       mission.plots * seeds,
+		Distance:    cost.distance,
+		FlightTime:  cost.time,
+		Energy:      cost.energy,
+		Refills:     refills,
+		Unreachable: buildPlotResponses(mission.unreachable),
+		Waypoints:   waypoints,
+	})
+}
+
+// The endpoint of retrieving the estate tree height heatmap, as a matrix or a PNG image
+// (GET /estate/{id}/heatmap)
+func (s *Server) GetEstateIdHeatmap(ctx echo.Context, id string, params generated.GetEstateIdHeatmapParams) error {
+	format := heatmapFormatJson
+	if params.Format != nil {
+		format = *params.Format
+	}
+
+	if format != heatmapFormatJson && format != heatmapFormatPng {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrInvalidHeatmapFormat.Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+		EstateId: id,
+		MinX:     1,
+		MaxX:     est.Length,
+		MinY:     1,
+		MaxY:     est.Width,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	maxHeight := findMaxHeight(trees.Trees)
+	grid := buildHeightGrid(trees.Trees, est.Length, est.Width)
+
+	if format == heatmapFormatPng {
+		img, err := renderHeatmapPng(grid, maxHeight)
+		if err != nil {
+			return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+
+		return ctx.Blob(http.StatusOK, "image/png", img)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.EstateHeatmapResponse{
+		Width:   est.Width,
+		Length:  est.Length,
+		Max:     maxHeight,
+		Heights: grid,
+	})
+}
+
+// The endpoint of rendering the estate plots, trees and drone flight path as an SVG image
+// (GET /estate/{id}/map.svg)
+func (s *Server) GetEstateIdMapSvg(ctx echo.Context, id string, params generated.GetEstateIdMapSvgParams) error {
+	if params.MaxDistance != nil && *params.MaxDistance <= 0 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrNegativeZeroBuilder("max_distance").Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+		EstateId: id,
+		MinX:     1,
+		MaxX:     est.Length,
+		MinY:     1,
+		MaxY:     est.Width,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	obstacles, err := s.Repository.GetEstateObstacles(ctx.Request().Context(), repository.GetEstateObstaclesInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	elevations, err := s.Repository.GetEstateElevations(ctx.Request().Context(), repository.GetEstateElevationsInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	maxHeight := findMaxHeight(trees.Trees)
+	grid := buildHeightGrid(trees.Trees, est.Length, est.Width)
+
+	// The map draws the same path the drone plan reports, ferries included
+	space := newAirspace(grid, obstacles.Obstacles, elevations.Elevations)
+	if est.HomeX.Valid {
+		space = space.withHome(int(est.HomeX.Int64), int(est.HomeY.Int64))
+	}
+	path := buildFlightPath(space)
+
+	var (
+		rest    flightWaypoint
+		hasRest bool
+	)
+	if params.MaxDistance != nil {
+		var i int
+		i, hasRest = findRestPoint(path, flightLimit{maxDistance: *params.MaxDistance}, defaultDroneProfile)
+		if hasRest {
+			rest = path[i]
+		}
+	}
+
+	return ctx.Blob(http.StatusOK, "image/svg+xml", renderEstateSvg(grid, maxHeight, obstacles.Obstacles, path, rest, hasRest))
+}
+
+// The endpoint of storing an obstacle or a no-fly plot in the estate
+// (POST /estate/{id}/obstacle)
+func (s *Server) PostEstateIdObstacle(ctx echo.Context, id string) error {
+	var req generated.CreateObstacleRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if req.X <= 0 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrNegativeZeroBuilder("x").Error(),
+		})
+	}
+
+	if req.Y <= 0 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrNegativeZeroBuilder("y").Error(),
+		})
+	}
+
+	obstacle := repository.EstateObstacle{
+		Id: uuid.New().String(),
+		X:  req.X,
+		Y:  req.Y,
+	}
+
+	if req.Height != nil {
+		if *req.Height <= 0 {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrNegativeZeroBuilder("height").Error(),
+			})
+		}
+
+		obstacle.Height = *req.Height
+	}
+
+	if req.NoFly != nil {
+		obstacle.NoFly = *req.NoFly
+	}
+
+	if req.Description != nil {
+		obstacle.Description = *req.Description
+	}
+
+	if obstacle.Height == 0 && !obstacle.NoFly {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrObstacleEmpty.Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if req.X > est.Length || req.Y > est.Width {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrCoordinateOutOfBound.Error(),
+		})
+	}
+
+	obstacles, err := s.Repository.GetEstateObstacles(ctx.Request().Context(), repository.GetEstateObstaclesInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	for _, existing := range obstacles.Obstacles {
+		if existing.X == req.X && existing.Y == req.Y {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrObstacleExist.Error(),
+			})
+		}
+	}
+
+	err = s.Repository.CreateObstacle(ctx.Request().Context(), repository.CreateObstacleInput{
+		EstateObstacle: obstacle,
+		EstateId:       id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, generated.UuidResponse{
+		Id: obstacle.Id,
+	})
+}
+
+// The endpoint of listing the estate obstacles and no-fly plots
+// (GET /estate/{id}/obstacle)
+func (s *Server) GetEstateIdObstacle(ctx echo.Context, id string) error {
+	_, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	obstacles, err := s.Repository.GetEstateObstacles(ctx.Request().Context(), repository.GetEstateObstaclesInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	resp := generated.EstateObstacleListResponse{
+		Obstacles: make([]generated.EstateObstacleResponse, len(obstacles.Obstacles)),
+	}
+	for i, obstacle := range obstacles.Obstacles {
+		resp.Obstacles[i] = generated.EstateObstacleResponse{
+			Id:          obstacle.Id,
+			X:           obstacle.X,
+			Y:           obstacle.Y,
+			Height:      obstacle.Height,
+			NoFly:       obstacle.NoFly,
+			Description: obstacle.Description,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// The endpoint of removing an obstacle from the estate
+// (DELETE /estate/{id}/obstacle/{obstacleId})
+func (s *Server) DeleteEstateIdObstacleObstacleId(ctx echo.Context, id string, obstacleId string) error {
+	err := s.Repository.DeleteObstacle(ctx.Request().Context(), repository.DeleteObstacleInput{
+		Id:       obstacleId,
+		EstateId: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("obstacle").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// The endpoint of uploading the ground elevation of every plot of the estate
+// (PUT /estate/{id}/elevation)
+func (s *Server) PutEstateIdElevation(ctx echo.Context, id string) error {
+	var req generated.EstateElevationRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	elevations, err := buildElevations(req.Elevations, est.Length, est.Width)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+		EstateId: id,
+		MinX:     1,
+		MaxX:     est.Length,
+		MinY:     1,
+		MaxY:     est.Width,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	prevElevations, err := s.Repository.GetEstateElevations(ctx.Request().Context(), repository.GetEstateElevationsInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	// The stored distance follows the trees and the terrain, so it moves by
+	// the difference of the path over the previous and the new terrain
+	grid := buildHeightGrid(trees.Trees, est.Length, est.Width)
+	err = s.Repository.StoreEstateElevations(ctx.Request().Context(), repository.StoreEstateElevationsInput{
+		EstateId:        id,
+		Elevations:      elevations,
+		DroneDistFactor: flightDistance(buildFlightPath(newAirspace(grid, nil, elevations))) - flightDistance(buildFlightPath(newAirspace(grid, nil, prevElevations.Elevations))),
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, generated.EstateElevationResponse{
+		Width:      est.Width,
+		Length:     est.Length,
+		Elevations: buildElevationGrid(elevations, est.Length, est.Width),
+	})
+}
+
+// The endpoint of retrieving the ground elevation of every plot of the estate
+// (GET /estate/{id}/elevation)
+func (s *Server) GetEstateIdElevation(ctx echo.Context, id string) error {
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	elevations, err := s.Repository.GetEstateElevations(ctx.Request().Context(), repository.GetEstateElevationsInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, generated.EstateElevationResponse{
+		Width:      est.Width,
+		Length:     est.Length,
+		Elevations: buildElevationGrid(elevations.Elevations, est.Length, est.Width),
+	})
+}
+
+// The endpoint of setting the home plot the drone takes off from and lands back on
+// (PUT /estate/{id}/home)
+func (s *Server) PutEstateIdHome(ctx echo.Context, id string) error {
+	var req generated.EstateHomeRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if err := validateHome(req.X, req.Y, est.Length, est.Width); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = s.Repository.StoreEstateHome(ctx.Request().Context(), repository.StoreEstateHomeInput{
+		EstateId: id,
+		X:        req.X,
+		Y:        req.Y,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, generated.EstateHomeResponse{
+		X: req.X,
+		Y: req.Y,
+	})
+}
+
+// The endpoint of setting the altitude ceiling the drone must stay under above the ground
+// (PUT /estate/{id}/ceiling)
+func (s *Server) PutEstateIdCeiling(ctx echo.Context, id string) error {
+	var req generated.EstateCeilingRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if req.Ceiling <= 0 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrNegativeZeroBuilder("ceiling").Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	// Only the trees taller than the ceiling allows are looked up
+	if est.Max+droneClearance > req.Ceiling {
+		trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     est.Length,
+			MinY:     1,
+			MaxY:     est.Width,
+		})
+		if err != nil {
+			return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+
+		canopy := make([]flightWaypoint, 0, len(trees.Trees))
+		for _, tree := range trees.Trees {
+			canopy = append(canopy, flightWaypoint{X: tree.X, Y: tree.Y, Altitude: tree.Height + droneClearance})
+		}
+
+		return ctx.JSON(http.StatusBadRequest, buildCeilingErrorResponse(req.Ceiling, ceilingViolations(canopy, req.Ceiling)))
+	}
+
+	err = s.Repository.StoreEstateCeiling(ctx.Request().Context(), repository.StoreEstateCeilingInput{
+		EstateId: id,
+		Ceiling:  req.Ceiling,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, generated.EstateCeilingResponse{
+		Ceiling: req.Ceiling,
+	})
+}
+
+// The endpoint of registering a drone profile
+// (POST /drone)
+func (s *Server) PostDrone(ctx echo.Context) error {
+	var req generated.DroneRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if err := validateDroneRequest(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	id := uuid.New().String()
+	err := s.Repository.CreateDrone(ctx.Request().Context(), repository.CreateDroneInput{
+		Drone: repository.Drone{
+			Id:              id,
+			Model:           req.Model,
+			MaxRange:        req.MaxRange,
+			MaxAltitude:     req.MaxAltitude,
+			CruiseSpeed:     req.CruiseSpeed,
+			ClimbRate:       req.ClimbRate,
+			BatteryCapacity: req.BatteryCapacity,
+		},
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, generated.UuidResponse{
+		Id: id,
+	})
+}
+
+// The endpoint of listing the registered drone profiles
+// (GET /drone)
+func (s *Server) GetDrone(ctx echo.Context) error {
+	drones, err := s.Repository.GetDrones(ctx.Request().Context(), repository.GetDronesInput{})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	resp := generated.DroneListResponse{
+		Drones: make([]generated.DroneResponse, len(drones.Drones)),
+	}
+	for i, drone := range drones.Drones {
+		resp.Drones[i] = buildDroneResponse(drone)
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// The endpoint of retrieving a drone profile
+// (GET /drone/{id})
+func (s *Server) GetDroneId(ctx echo.Context, id string) error {
+	drone, err := s.Repository.GetDroneById(ctx.Request().Context(), repository.GetDroneByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("drone").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, buildDroneResponse(drone.Drone))
+}
+
+// The endpoint of updating a drone profile
+// (PUT /drone/{id})
+func (s *Server) PutDroneId(ctx echo.Context, id string) error {
+	var req generated.DroneRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if err := validateDroneRequest(req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	drone := repository.Drone{
+		Id:              id,
+		Model:           req.Model,
+		MaxRange:        req.MaxRange,
+		MaxAltitude:     req.MaxAltitude,
+		CruiseSpeed:     req.CruiseSpeed,
+		ClimbRate:       req.ClimbRate,
+		BatteryCapacity: req.BatteryCapacity,
+	}
+
+	err := s.Repository.UpdateDrone(ctx.Request().Context(), repository.UpdateDroneInput{
+		Drone: drone,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("drone").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, buildDroneResponse(drone))
+}
+
+// The endpoint of removing a drone profile
+// (DELETE /drone/{id})
+func (s *Server) DeleteDroneId(ctx echo.Context, id string) error {
+	_, err := s.Repository.GetDroneById(ctx.Request().Context(), repository.GetDroneByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("drone").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	// The drone is still there, so it flies a mission that has not ended
+	err = s.Repository.DeleteDrone(ctx.Request().Context(), repository.DeleteDroneInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusConflict, generated.ErrorResponse{
+				Message: ErrDroneInMission.Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// The endpoint of scheduling a mission flying a snapshot of the estate drone
+// plan, the latest one by default
+// (POST /mission)
+func (s *Server) PostMission(ctx echo.Context) error {
+	var req generated.CreateMissionRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if req.EstateId == "" {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrEmptyBuilder("estate_id").Error(),
+		})
+	}
+
+	var version int
+	if req.SnapshotVersion != nil {
+		if *req.SnapshotVersion <= 0 {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrNegativeZeroBuilder("snapshot_version").Error(),
+			})
+		}
+
+		version = *req.SnapshotVersion
+	}
+
+	snapshot, err := s.Repository.GetDronePlanSnapshot(ctx.Request().Context(), repository.GetDronePlanSnapshotInput{
+		EstateId: req.EstateId,
+		Version:  version,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("snapshot").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	mission := repository.Mission{
+		Id:              uuid.New().String(),
+		EstateId:        req.EstateId,
+		SnapshotId:      snapshot.Id,
+		SnapshotVersion: snapshot.Version,
+		Status:          missionStatusScheduled,
+	}
+
+	if req.DroneId != nil {
+		_, err := s.Repository.GetDroneById(ctx.Request().Context(), repository.GetDroneByIdInput{
+			Id: *req.DroneId,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+					Message: ErrNotFoundBuilder("drone").Error(),
+				})
+			}
+
+			return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+
+		mission.DroneId = sql.NullString{String: *req.DroneId, Valid: true}
+	}
+
+	created, err := s.Repository.CreateMission(ctx.Request().Context(), repository.CreateMissionInput{
+		Mission: mission,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	mission.CreatedAt = created.CreatedAt
+
+	return ctx.JSON(http.StatusCreated, buildMissionResponse(mission))
+}
+
+// The endpoint of listing the missions, by estate or status when given
+// (GET /mission)
+func (s *Server) GetMission(ctx echo.Context, params generated.GetMissionParams) error {
+	var input repository.GetMissionsInput
+	if params.EstateId != nil {
+		input.EstateId = *params.EstateId
+	}
+
+	if params.Status != nil {
+		if !isMissionStatus(*params.Status) {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrInvalidMissionStatus.Error(),
+			})
+		}
+
+		input.Status = *params.Status
+	}
+
+	missions, err := s.Repository.GetMissions(ctx.Request().Context(), input)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	resp := generated.MissionListResponse{
+		Missions: make([]generated.MissionResponse, len(missions.Missions)),
+	}
+	for i, mission := range missions.Missions {
+		resp.Missions[i] = buildMissionResponse(mission)
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// The endpoint of retrieving a mission
+// (GET /mission/{id})
+func (s *Server) GetMissionId(ctx echo.Context, id string) error {
+	mission, err := s.Repository.GetMissionById(ctx.Request().Context(), repository.GetMissionByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("mission").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, buildMissionResponse(mission.Mission))
+}
+
+// The endpoint of assigning the drone flying a scheduled mission
+// (PUT /mission/{id}/drone)
+func (s *Server) PutMissionIdDrone(ctx echo.Context, id string) error {
+	var req generated.MissionDroneRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if req.DroneId == "" {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrEmptyBuilder("drone_id").Error(),
+		})
+	}
+
+	mission, err := s.Repository.GetMissionById(ctx.Request().Context(), repository.GetMissionByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("mission").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if mission.Status != missionStatusScheduled {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrMissionNotScheduled.Error(),
+		})
+	}
+
+	_, err = s.Repository.GetDroneById(ctx.Request().Context(), repository.GetDroneByIdInput{
+		Id: req.DroneId,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("drone").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = s.Repository.UpdateMissionDrone(ctx.Request().Context(), repository.UpdateMissionDroneInput{
+		Id:      id,
+		DroneId: req.DroneId,
+	})
+	if err != nil {
+		// The mission started or was aborted since it was read
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrMissionNotScheduled.Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	mission.DroneId = sql.NullString{String: req.DroneId, Valid: true}
+
+	return ctx.JSON(http.StatusOK, buildMissionResponse(mission.Mission))
+}
+
+// The endpoint of moving a mission through scheduled, in_progress, then
+// completed or aborted with a reason
+// (PUT /mission/{id}/status)
+func (s *Server) PutMissionIdStatus(ctx echo.Context, id string) error {
+	var req generated.MissionStatusRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if !isMissionStatus(req.Status) {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrInvalidMissionStatus.Error(),
+		})
+	}
+
+	var reason sql.NullString
+	if req.Status == missionStatusAborted {
+		if req.Reason == nil || *req.Reason == "" {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrEmptyBuilder("reason").Error(),
+			})
+		}
+
+		reason = sql.NullString{String: *req.Reason, Valid: true}
+	} else if req.Reason != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrParamConflictBuilder("reason", req.Status).Error(),
+		})
+	}
+
+	mission, err := s.Repository.GetMissionById(ctx.Request().Context(), repository.GetMissionByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("mission").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if !canMoveMission(mission.Status, req.Status) {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrMissionTransitionBuilder(mission.Status, req.Status).Error(),
+		})
+	}
+
+	if req.Status == missionStatusInProgress && !mission.DroneId.Valid {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrMissionWithoutDrone.Error(),
+		})
+	}
+
+	updated, err := s.Repository.UpdateMissionStatus(ctx.Request().Context(), repository.UpdateMissionStatusInput{
+		Id:          id,
+		From:        mission.Status,
+		To:          req.Status,
+		AbortReason: reason,
+	})
+	if err != nil {
+		// The mission moved since it was read
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrMissionTransitionBuilder(mission.Status, req.Status).Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	mission.Status, mission.AbortReason = req.Status, reason
+	mission.StartedAt, mission.EndedAt = updated.StartedAt, updated.EndedAt
+
+	resp := buildMissionResponse(mission.Mission)
+	s.Hub.publish(id, missionEvent{name: missionEventStatus, data: resp})
+
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// The endpoint of storing a batch of the telemetry the drone reports while
+// flying a mission
+// (POST /mission/{id}/telemetry)
+func (s *Server) PostMissionIdTelemetry(ctx echo.Context, id string) error {
+	var req generated.TelemetryBatchRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if len(req.Points) == 0 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrEmptyBuilder("points").Error(),
+		})
+	}
+
+	for _, point := range req.Points {
+		if point.Timestamp.IsZero() {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrEmptyBuilder("timestamp").Error(),
+			})
+		}
+
+		if point.Battery < 0 || point.Battery > 100 {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrInvalidBattery.Error(),
+			})
+		}
+	}
+
+	mission, err := s.Repository.GetMissionById(ctx.Request().Context(), repository.GetMissionByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("mission").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if mission.Status == missionStatusScheduled {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrMissionNotStarted.Error(),
+		})
+	}
+
+	est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: mission.EstateId,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	// The drone flies over the estate, or over its home when it is at the
+	// boundary
+	points := make([]repository.TelemetryPoint, 0, len(req.Points))
+	for _, point := range req.Points {
+		inside := point.X >= 1 && point.X <= est.Length && point.Y >= 1 && point.Y <= est.Width
+		atHome := est.HomeX.Valid && int64(point.X) == est.HomeX.Int64 && int64(point.Y) == est.HomeY.Int64
+		if !inside && !atHome {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrCoordinateOutOfBound.Error(),
+			})
+		}
+
+		points = append(points, repository.TelemetryPoint{
+			RecordedAt: point.Timestamp,
+			X:          point.X,
+			Y:          point.Y,
+			Altitude:   point.Altitude,
+			Battery:    point.Battery,
+		})
+	}
+
+	stored, err := s.Repository.StoreMissionTelemetry(ctx.Request().Context(), repository.StoreMissionTelemetryInput{
+		MissionId: id,
+		Points:    points,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	s.Hub.publish(id, missionEvent{name: missionEventTelemetry, data: buildTelemetryEvent(points)})
+
+	return ctx.JSON(http.StatusOK, generated.TelemetryBatchResponse{
+		Received: len(points),
+		Stored:   stored.Stored,
+	})
+}
+
+// The endpoint of comparing the track the drone reported against the plan
+// snapshot of the mission
+// (GET /mission/{id}/deviation)
+func (s *Server) GetMissionIdDeviation(ctx echo.Context, id string) error {
+	mission, err := s.Repository.GetMissionById(ctx.Request().Context(), repository.GetMissionByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("mission").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	snapshot, err := s.Repository.GetDronePlanSnapshot(ctx.Request().Context(), repository.GetDronePlanSnapshotInput{
+		EstateId: mission.EstateId,
+		Version:  mission.SnapshotVersion,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	telemetry, err := s.Repository.GetMissionTelemetry(ctx.Request().Context(), repository.GetMissionTelemetryInput{
+		MissionId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, buildDeviationResponse(buildDeviationReport(snapshot.Waypoints, telemetry.Points)))
+}
+
+// The endpoint of streaming the telemetry and the status changes of a mission
+// as they are ingested
+// (GET /mission/{id}/stream)
+func (s *Server) GetMissionIdStream(ctx echo.Context, id string) error {
+	// Subscribing before reading the mission keeps a change made in between
+	// from being missed
+	events, unsubscribe := s.Hub.subscribe(id)
+	defer unsubscribe()
+
+	mission, err := s.Repository.GetMissionById(ctx.Request().Context(), repository.GetMissionByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("mission").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.WriteHeader(http.StatusOK)
+
+	// The stream ends with the status that ends the mission
+	send := func(event missionEvent) (ended bool, err error) {
+		if err := writeMissionEvent(res, event); err != nil {
+			return false, err
+		}
+
+		status, ok := event.data.(generated.MissionResponse)
+		return ok && isMissionEnded(status.Status), nil
+	}
+
+	if ended, err := send(missionEvent{name: missionEventStatus, data: buildMissionResponse(mission.Mission)}); ended || err != nil {
+		return err
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request().Context().Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return err
+			}
+
+			res.Flush()
+		case event, ok := <-events:
+			// The hub closes the events of a viewer that fell behind on a
+			// status, the viewer reconnects to read the mission again
+			if !ok {
+				return nil
+			}
+
+			if ended, err := send(event); ended || err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// The endpoint of recording what the crew observed on a tree
+// (POST /estate/{id}/tree/{treeId}/observation)
+func (s *Server) PostEstateIdTreeTreeIdObservation(ctx echo.Context, id string, treeId string) error {
+	var req generated.CreateTreeObservationRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if req.HealthScore < 1 || req.HealthScore > 10 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrHealthOutOfRange.Error(),
+		})
+	}
+
+	observation := repository.CreateTreeObservationInput{
+		Id:          uuid.New().String(),
+		TreeId:      treeId,
+		HealthScore: req.HealthScore,
+	}
+
+	if req.FruitCount != nil {
+		if *req.FruitCount < 0 {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrNegativeBuilder("fruit_count").Error(),
+			})
+		}
+
+		observation.FruitCount = sql.NullInt64{Int64: int64(*req.FruitCount), Valid: true}
+	}
+
+	if req.Pests != nil {
+		for _, pest := range *req.Pests {
+			if pest == "" {
+				return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+					Message: ErrEmptyBuilder("pest").Error(),
+				})
+			}
+		}
+
+		observation.Pests = *req.Pests
+	}
+
+	if req.SuspectedDisease != nil && *req.SuspectedDisease != "" {
+		observation.SuspectedDisease = sql.NullString{String: *req.SuspectedDisease, Valid: true}
+	}
+
+	if req.Notes != nil && *req.Notes != "" {
+		observation.Notes = sql.NullString{String: *req.Notes, Valid: true}
+	}
+
+	if req.ObservedAt != nil {
+		observation.ObservedAt = sql.NullTime{Time: *req.ObservedAt, Valid: true}
+	}
+
+	tree, err := s.Repository.GetTreeById(ctx.Request().Context(), repository.GetTreeByIdInput{
+		Id:       treeId,
+		EstateId: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("tree").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	// A felled tree keeps the observations it had, but gets no new one
+	if tree.Status == treeStatusFelled {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrTreeFelled.Error(),
+		})
+	}
+
+	if req.MissionId != nil {
+		mission, err := s.Repository.GetMissionById(ctx.Request().Context(), repository.GetMissionByIdInput{
+			Id: *req.MissionId,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+					Message: ErrNotFoundBuilder("mission").Error(),
+				})
+			}
+
+			return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+
+		if mission.EstateId != id {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrMissionOtherEstate.Error(),
+			})
+		}
+
+		observation.MissionId = sql.NullString{String: mission.Id, Valid: true}
+	}
+
+	err = s.Repository.CreateTreeObservation(ctx.Request().Context(), observation)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, generated.UuidResponse{
+		Id: observation.Id,
+	})
+}
+
+// The endpoint of listing the tree observations of the estate, newest first,
+// by tree or mission when given
+// (GET /estate/{id}/observation)
+func (s *Server) GetEstateIdObservation(ctx echo.Context, id string, params generated.GetEstateIdObservationParams) error {
+	_, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	input := repository.GetTreeObservationsInput{
+		EstateId: id,
+	}
+	if params.TreeId != nil {
+		input.TreeId = *params.TreeId
+	}
+	if params.MissionId != nil {
+		input.MissionId = *params.MissionId
+	}
+
+	observations, err := s.Repository.GetTreeObservations(ctx.Request().Context(), input)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	resp := generated.TreeObservationListResponse{
+		Observations: make([]generated.TreeObservationResponse, len(observations.Observations)),
+	}
+	for i, observation := range observations.Observations {
+		resp.Observations[i] = buildObservationResponse(observation)
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// The endpoint of getting the latest observed health of every observed tree
+// of the estate
+// (GET /estate/{id}/tree-health)
+func (s *Server) GetEstateIdTreeHealth(ctx echo.Context, id string) error {
+	_, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("estate").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	latest, err := s.Repository.GetLatestTreeObservations(ctx.Request().Context(), repository.GetLatestTreeObservationsInput{
+		EstateId: id,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	resp := generated.TreeHealthListResponse{
+		Trees: make([]generated.TreeObservationResponse, len(latest.Observations)),
+	}
+	for i, observation := range latest.Observations {
+		resp.Trees[i] = buildObservationResponse(observation)
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// The endpoint of adding a tree species to the catalogue
+// (POST /species)
+func (s *Server) PostSpecies(ctx echo.Context) error {
+	var req generated.CreateSpeciesRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if req.Name == "" {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrEmptyBuilder("name").Error(),
+		})
+	}
+
+	if req.MaxHeight <= 0 {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrNegativeZeroBuilder("max_height").Error(),
+		})
+	}
+
+	growthProfile := growthProfileModerate
+	if req.GrowthProfile != nil {
+		if !isGrowthProfile(*req.GrowthProfile) {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrInvalidGrowthProfile.Error(),
+			})
+		}
+
+		growthProfile = *req.GrowthProfile
+	}
+
+	id := uuid.New().String()
+	err := s.Repository.CreateSpecies(ctx.Request().Context(), repository.CreateSpeciesInput{
+		Species: repository.Species{
+			Id:            id,
+			Name:          req.Name,
+			MaxHeight:     req.MaxHeight,
+			GrowthProfile: growthProfile,
+		},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrSpeciesExist.Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, generated.UuidResponse{
+		Id: id,
+	})
+}
+
+// The endpoint of listing the tree species catalogue
+// (GET /species)
+func (s *Server) GetSpecies(ctx echo.Context) error {
+	catalogue, err := s.Repository.GetAllSpecies(ctx.Request().Context(), repository.GetAllSpeciesInput{})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	resp := generated.SpeciesListResponse{
+		Species: make([]generated.SpeciesResponse, len(catalogue.Species)),
+	}
+	for i, species := range catalogue.Species {
+		resp.Species[i] = buildSpeciesResponse(species)
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// The endpoint of retrieving a tree species
+// (GET /species/{id})
+func (s *Server) GetSpeciesId(ctx echo.Context, id string) error {
+	species, err := s.Repository.GetSpeciesById(ctx.Request().Context(), repository.GetSpeciesByIdInput{
+		Id: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("species").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, buildSpeciesResponse(species.Species))
+}
+
+// The endpoint of retrieving a tree of the estate, felled or not
+// (GET /estate/{id}/tree/{treeId})
+func (s *Server) GetEstateIdTreeTreeId(ctx echo.Context, id string, treeId string) error {
+	tree, err := s.Repository.GetTreeById(ctx.Request().Context(), repository.GetTreeByIdInput{
+		Id:       treeId,
+		EstateId: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("tree").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, buildTreeResponse(tree.EstateTree))
+}
+
+// The endpoint of moving a tree through its lifecycle. A felled tree is kept
+// for its history, but leaves the stats and the drone path of the estate.
+// (PUT /estate/{id}/tree/{treeId}/status)
+func (s *Server) PutEstateIdTreeTreeIdStatus(ctx echo.Context, id string, treeId string) error {
+	var req generated.TreeStatusRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if !isTreeStatus(req.Status) {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrInvalidTreeStatus.Error(),
+		})
+	}
+
+	var reason sql.NullString
+	if req.Reason != nil && *req.Reason != "" {
+		reason = sql.NullString{String: *req.Reason, Valid: true}
+	}
+
+	tree, err := s.Repository.GetTreeById(ctx.Request().Context(), repository.GetTreeByIdInput{
+		Id:       treeId,
+		EstateId: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("tree").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if !canMoveTree(tree.Status, req.Status) {
+		return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Message: ErrTreeTransitionBuilder(tree.Status, req.Status).Error(),
+		})
+	}
+
+	var droneDistFactor int
+	if req.Status == treeStatusFelled {
+		est, err := s.Repository.GetEstateById(ctx.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		})
+		if err != nil {
+			return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+
+		trees, err := s.Repository.GetEstateTrees(ctx.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     est.Length,
+			MinY:     1,
+			MaxY:     est.Width,
+		})
+		if err != nil {
+			return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+
+		elevations, err := s.Repository.GetEstateElevations(ctx.Request().Context(), repository.GetEstateElevationsInput{
+			EstateId: id,
+		})
+		if err != nil {
+			return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+
+		// The stored distance moves by the difference of the path over the
+		// estate with and without the tree
+		grid := buildHeightGrid(trees.Trees, est.Length, est.Width)
+		before := flightDistance(buildFlightPath(newAirspace(grid, nil, elevations.Elevations)))
+		grid[tree.Y-1][tree.X-1] = 0
+		droneDistFactor = flightDistance(buildFlightPath(newAirspace(grid, nil, elevations.Elevations))) - before
+	}
+
+	err = s.Repository.UpdateTreeStatus(ctx.Request().Context(), repository.UpdateTreeStatusInput{
+		Id:       treeId,
+		EstateId: id,
+		From:     tree.Status,
+		To:       req.Status,
+		Reason:   reason,
+
+		DroneDistFactor: droneDistFactor,
+	})
+	if err != nil {
+		// The tree moved since it was read
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Message: ErrTreeTransitionBuilder(tree.Status, req.Status).Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	tree.Status = req.Status
+
+	return ctx.JSON(http.StatusOK, buildTreeResponse(tree.EstateTree))
+}
+
+// The endpoint of listing every status a tree has been through since it was
+// planted
+// (GET /estate/{id}/tree/{treeId}/history)
+func (s *Server) GetEstateIdTreeTreeIdHistory(ctx echo.Context, id string, treeId string) error {
+	_, err := s.Repository.GetTreeById(ctx.Request().Context(), repository.GetTreeByIdInput{
+		Id:       treeId,
+		EstateId: id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Message: ErrNotFoundBuilder("tree").Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	history, err := s.Repository.GetTreeStatusHistory(ctx.Request().Context(), repository.GetTreeStatusHistoryInput{
+		TreeId: treeId,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	resp := generated.TreeStatusHistoryResponse{
+		History: make([]generated.TreeStatusChangeResponse, len(history.History)),
+	}
+	for i, change := range history.History {
+		resp.History[i] = buildTreeStatusChangeResponse(change)
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
 }
@@ -1,14 +1,19 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	"image/png"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -103,6 +108,119 @@ func TestPostEstate(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("Return 201 with elevations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate", strings.NewReader("{\"length\": 3, \"width\": 2, \"elevations\": [[0, 5, 5], [2, 0, 0]]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().CreateEstate(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ any, input repository.CreateEstateInput) error {
+			assert.Equal(t, []repository.PlotElevation{
+				{X: 2, Y: 1, Elevation: 5},
+				{X: 3, Y: 1, Elevation: 5},
+				{X: 1, Y: 2, Elevation: 2},
+			}, input.Elevations)
+			// 0 -> 5 -> 5 -> 0 -> 0 -> 2 along the path
+			assert.Equal(t, 12, input.ElevationDistance)
+			assert.Equal(t, defaultCeiling, input.Ceiling)
+
+			return nil
+		})
+
+		err := server.PostEstate(ec)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+	})
+
+	t.Run("Return 201 with a lower ceiling", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate", strings.NewReader("{\"length\": 3, \"width\": 2, \"ceiling\": 60}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().CreateEstate(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ any, input repository.CreateEstateInput) error {
+			assert.Equal(t, 60, input.Ceiling)
+
+			return nil
+		})
+
+		err := server.PostEstate(ec)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+	})
+
+	t.Run("Return 400 when ceiling is zero", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate", strings.NewReader("{\"length\": 3, \"width\": 2, \"ceiling\": 0}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstate(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("ceiling").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when elevations do not match the estate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate", strings.NewReader("{\"length\": 3, \"width\": 2, \"elevations\": [[0, 5, 5], [2, 0]]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstate(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrElevationDimension.Error(), resp["message"])
+	})
+
 	t.Run("Return 500 when create estate error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -339,16 +457,20 @@ func TestPostEstateIdTree(t *testing.T) {
 			Width:  6,
 		}, nil)
 		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), repository.CountCoordinateTreeInput{
-			X: bodyReq.X,
-			Y: bodyReq.Y,
+			EstateId: id,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
 		}).Return(repository.CountCoordinateTreeOutput{
 			Count: 0,
 		}, nil)
 		mockRepo.EXPECT().GetPrevNextTree(ec.Request().Context(), repository.GetPrevNextTreeInput{
-			PrevX: 1,
-			PrevY: 1,
-			NextX: 3,
-			NextY: 1,
+			EstateId: id,
+			PrevX:    1,
+			PrevY:    1,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
+			NextX:    3,
+			NextY:    1,
 		}).Return(repository.GetPrevNextTreeOutput{
 			PrevTreeHeight: 0,
 			NextTreeHeight: 0,
@@ -398,16 +520,20 @@ func TestPostEstateIdTree(t *testing.T) {
 			Width:  6,
 		}, nil)
 		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), repository.CountCoordinateTreeInput{
-			X: bodyReq.X,
-			Y: bodyReq.Y,
+			EstateId: id,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
 		}).Return(repository.CountCoordinateTreeOutput{
 			Count: 0,
 		}, nil)
 		mockRepo.EXPECT().GetPrevNextTree(ec.Request().Context(), repository.GetPrevNextTreeInput{
-			PrevX: 1,
-			PrevY: 0,
-			NextX: 2,
-			NextY: 1,
+			EstateId: id,
+			PrevX:    1,
+			PrevY:    0,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
+			NextX:    2,
+			NextY:    1,
 		}).Return(repository.GetPrevNextTreeOutput{
 			PrevTreeHeight: 0,
 			NextTreeHeight: 0,
@@ -457,16 +583,20 @@ func TestPostEstateIdTree(t *testing.T) {
 			Width:  6,
 		}, nil)
 		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), repository.CountCoordinateTreeInput{
-			X: bodyReq.X,
-			Y: bodyReq.Y,
+			EstateId: id,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
 		}).Return(repository.CountCoordinateTreeOutput{
 			Count: 0,
 		}, nil)
 		mockRepo.EXPECT().GetPrevNextTree(ec.Request().Context(), repository.GetPrevNextTreeInput{
-			PrevX: 5,
-			PrevY: 1,
-			NextX: 6,
-			NextY: 2,
+			EstateId: id,
+			PrevX:    5,
+			PrevY:    1,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
+			NextX:    6,
+			NextY:    2,
 		}).Return(repository.GetPrevNextTreeOutput{
 			PrevTreeHeight: 0,
 			NextTreeHeight: 0,
@@ -516,16 +646,20 @@ func TestPostEstateIdTree(t *testing.T) {
 			Width:  6,
 		}, nil)
 		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), repository.CountCoordinateTreeInput{
-			X: bodyReq.X,
-			Y: bodyReq.Y,
+			EstateId: id,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
 		}).Return(repository.CountCoordinateTreeOutput{
 			Count: 0,
 		}, nil)
 		mockRepo.EXPECT().GetPrevNextTree(ec.Request().Context(), repository.GetPrevNextTreeInput{
-			PrevX: 6,
-			PrevY: 1,
-			NextX: 5,
-			NextY: 2,
+			EstateId: id,
+			PrevX:    6,
+			PrevY:    1,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
+			NextX:    5,
+			NextY:    2,
 		}).Return(repository.GetPrevNextTreeOutput{
 			PrevTreeHeight: 0,
 			NextTreeHeight: 0,
@@ -575,16 +709,20 @@ func TestPostEstateIdTree(t *testing.T) {
 			Width:  6,
 		}, nil)
 		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), repository.CountCoordinateTreeInput{
-			X: bodyReq.X,
-			Y: bodyReq.Y,
+			EstateId: id,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
 		}).Return(repository.CountCoordinateTreeOutput{
 			Count: 0,
 		}, nil)
 		mockRepo.EXPECT().GetPrevNextTree(ec.Request().Context(), repository.GetPrevNextTreeInput{
-			PrevX: 4,
-			PrevY: 2,
-			NextX: 2,
-			NextY: 2,
+			EstateId: id,
+			PrevX:    4,
+			PrevY:    2,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
+			NextX:    2,
+			NextY:    2,
 		}).Return(repository.GetPrevNextTreeOutput{
 			PrevTreeHeight: 0,
 			NextTreeHeight: 0,
@@ -634,16 +772,20 @@ func TestPostEstateIdTree(t *testing.T) {
 			Width:  6,
 		}, nil)
 		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), repository.CountCoordinateTreeInput{
-			X: bodyReq.X,
-			Y: bodyReq.Y,
+			EstateId: id,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
 		}).Return(repository.CountCoordinateTreeOutput{
 			Count: 0,
 		}, nil)
 		mockRepo.EXPECT().GetPrevNextTree(ec.Request().Context(), repository.GetPrevNextTreeInput{
-			PrevX: 2,
-			PrevY: 2,
-			NextX: 1,
-			NextY: 3,
+			EstateId: id,
+			PrevX:    2,
+			PrevY:    2,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
+			NextX:    1,
+			NextY:    3,
 		}).Return(repository.GetPrevNextTreeOutput{
 			PrevTreeHeight: 0,
 			NextTreeHeight: 0,
@@ -662,6 +804,119 @@ func TestPostEstateIdTree(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("Return 201 with elevation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate", strings.NewReader("{\"x\": 2, \"y\": 1, \"height\": 10}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		bodyReq := generated.CreateTreeRequest{
+			X:      2,
+			Y:      1,
+			Height: 10,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{
+			Length: 6,
+			Width:  6,
+		}, nil)
+		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), gomock.Any()).Return(repository.CountCoordinateTreeOutput{}, nil)
+		mockRepo.EXPECT().GetPrevNextTree(ec.Request().Context(), repository.GetPrevNextTreeInput{
+			EstateId: id,
+			PrevX:    1,
+			PrevY:    1,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
+			NextX:    3,
+			NextY:    1,
+		}).Return(repository.GetPrevNextTreeOutput{
+			NextTreeHeight: 3,
+			Elevation:      4,
+			NextElevation:  8,
+		}, nil)
+		mockRepo.EXPECT().CreateTree(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ any, input repository.CreateTreeInput) error {
+			// The drone flies at 15m above the plot instead of 5m, between 1m and 12m
+			assert.Equal(t, 6, input.DroneDistFactor)
+
+			return nil
+		})
+
+		err := server.PostEstateIdTree(ec, id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+	})
+
+	t.Run("Return 201 with elevation on the takeoff plot", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate", strings.NewReader("{\"x\": 1, \"y\": 1, \"height\": 10}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		bodyReq := generated.CreateTreeRequest{
+			X:      1,
+			Y:      1,
+			Height: 10,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{
+			Length: 6,
+			Width:  6,
+		}, nil)
+		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), gomock.Any()).Return(repository.CountCoordinateTreeOutput{}, nil)
+		mockRepo.EXPECT().GetPrevNextTree(ec.Request().Context(), repository.GetPrevNextTreeInput{
+			EstateId: id,
+			PrevX:    1,
+			PrevY:    0,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
+			NextX:    2,
+			NextY:    1,
+		}).Return(repository.GetPrevNextTreeOutput{
+			Elevation:     4,
+			NextElevation: 4,
+		}, nil)
+		mockRepo.EXPECT().CreateTree(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ any, input repository.CreateTreeInput) error {
+			// The drone takes off from the 4m plot and climbs 10m more, then descends it
+			assert.Equal(t, 20, input.DroneDistFactor)
+
+			return nil
+		})
+
+		err := server.PostEstateIdTree(ec, id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+	})
+
 	t.Run("Return 500 when create tree error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -695,16 +950,20 @@ func TestPostEstateIdTree(t *testing.T) {
 			Width:  6,
 		}, nil)
 		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), repository.CountCoordinateTreeInput{
-			X: bodyReq.X,
-			Y: bodyReq.Y,
+			EstateId: id,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
 		}).Return(repository.CountCoordinateTreeOutput{
 			Count: 0,
 		}, nil)
 		mockRepo.EXPECT().GetPrevNextTree(ec.Request().Context(), repository.GetPrevNextTreeInput{
-			PrevX: 1,
-			PrevY: 1,
-			NextX: 3,
-			NextY: 1,
+			EstateId: id,
+			PrevX:    1,
+			PrevY:    1,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
+			NextX:    3,
+			NextY:    1,
 		}).Return(repository.GetPrevNextTreeOutput{
 			PrevTreeHeight: 0,
 			NextTreeHeight: 0,
@@ -753,16 +1012,20 @@ func TestPostEstateIdTree(t *testing.T) {
 			Width:  6,
 		}, nil)
 		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), repository.CountCoordinateTreeInput{
-			X: bodyReq.X,
-			Y: bodyReq.Y,
+			EstateId: id,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
 		}).Return(repository.CountCoordinateTreeOutput{
 			Count: 0,
 		}, nil)
 		mockRepo.EXPECT().GetPrevNextTree(ec.Request().Context(), repository.GetPrevNextTreeInput{
-			PrevX: 1,
-			PrevY: 1,
-			NextX: 3,
-			NextY: 1,
+			EstateId: id,
+			PrevX:    1,
+			PrevY:    1,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
+			NextX:    3,
+			NextY:    1,
 		}).Return(repository.GetPrevNextTreeOutput{}, anyErr)
 
 		err := server.PostEstateIdTree(ec, id)
@@ -805,8 +1068,9 @@ func TestPostEstateIdTree(t *testing.T) {
 			Width:  6,
 		}, nil)
 		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), repository.CountCoordinateTreeInput{
-			X: bodyReq.X,
-			Y: bodyReq.Y,
+			EstateId: id,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
 		}).Return(repository.CountCoordinateTreeOutput{
 			Count: 1,
 		}, nil)
@@ -853,8 +1117,9 @@ func TestPostEstateIdTree(t *testing.T) {
 			Width:  6,
 		}, nil)
 		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), repository.CountCoordinateTreeInput{
-			X: bodyReq.X,
-			Y: bodyReq.Y,
+			EstateId: id,
+			X:        bodyReq.X,
+			Y:        bodyReq.Y,
 		}).Return(repository.CountCoordinateTreeOutput{}, anyErr)
 
 		err := server.PostEstateIdTree(ec, id)
@@ -866,6 +1131,47 @@ func TestPostEstateIdTree(t *testing.T) {
 		assert.Equal(t, anyErr.Error(), resp["message"])
 	})
 
+	t.Run("Return 400 when the tree reaches above the ceiling", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate", strings.NewReader("{\"x\": 2, \"y\": 3, \"height\": 25}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{
+			Length:  6,
+			Width:   6,
+			Ceiling: 20,
+		}, nil)
+
+		err := server.PostEstateIdTree(ec, id)
+
+		resp := readJson[generated.CeilingErrorResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, generated.CeilingErrorResponse{
+			Message: ErrCeilingExceeded.Error(),
+			Ceiling: 20,
+			Plots: []generated.CeilingViolationResponse{
+				{X: 2, Y: 3, Altitude: 26},
+			},
+		}, resp)
+	})
+
 	t.Run("Return 400 when x out of bound", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -1104,17 +1410,16 @@ func TestPostEstateIdTree(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
 		assert.Equal(t, "code=415, message=Unsupported Media Type", resp["message"])
 	})
-}
 
-func TestGetEstateIdStats(t *testing.T) {
-	t.Run("Return 200 when median exists", func(t *testing.T) {
+	t.Run("Return 201 with the species of the tree", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats", nil)
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree", strings.NewReader("{\"x\": 2, \"y\": 1, \"height\": 11, \"species_id\": \"palm\"}"))
 		resRecorder := httptest.NewRecorder()
 
 		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
 
 		mockRepo := repository.NewMockRepositoryInterface(ctrl)
 
@@ -1122,39 +1427,37 @@ func TestGetEstateIdStats(t *testing.T) {
 			Repository: mockRepo,
 		}
 
-		id := uuid.New().String()
-
-		estRep := repository.GetEstateByIdOutput{
-			Count:  5,
-			Max:    11,
-			Min:    1,
-			Median: 3,
-		}
+		id, speciesId := uuid.New().String(), "palm"
 
+		mockRepo.EXPECT().GetSpeciesById(ec.Request().Context(), repository.GetSpeciesByIdInput{
+			Id: speciesId,
+		}).Return(repository.GetSpeciesByIdOutput{Species: repository.Species{Id: speciesId, Name: "Oil Palm", MaxHeight: 12}}, nil)
 		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
 			Id: id,
-		}).Return(estRep, nil)
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 6, Width: 6}, nil)
+		mockRepo.EXPECT().CountCoordinateTree(ec.Request().Context(), gomock.Any()).Return(repository.CountCoordinateTreeOutput{}, nil)
+		mockRepo.EXPECT().GetPrevNextTree(ec.Request().Context(), gomock.Any()).Return(repository.GetPrevNextTreeOutput{}, nil)
+		mockRepo.EXPECT().CreateTree(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ any, input repository.CreateTreeInput) error {
+			assert.Equal(t, sql.NullString{String: speciesId, Valid: true}, input.SpeciesId)
 
-		err := server.GetEstateIdStats(ec, id)
+			return nil
+		})
 
-		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
+		err := server.PostEstateIdTree(ec, id)
 
 		assert.Nil(t, err)
-		assert.Equal(t, http.StatusOK, resRecorder.Code)
-		assert.Equal(t, estRep.Count, resp.Count)
-		assert.Equal(t, estRep.Max, resp.Max)
-		assert.Equal(t, estRep.Median, resp.Median)
-		assert.Equal(t, estRep.Min, resp.Min)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
 	})
 
-	t.Run("Return 200 when median is not exist and data is odd", func(t *testing.T) {
+	t.Run("Return 400 when height is over the species max height", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats", nil)
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree", strings.NewReader("{\"x\": 2, \"y\": 1, \"height\": 11, \"species_id\": \"palm\"}"))
 		resRecorder := httptest.NewRecorder()
 
 		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
 
 		mockRepo := repository.NewMockRepositoryInterface(ctrl)
 
@@ -1162,32 +1465,134 @@ func TestGetEstateIdStats(t *testing.T) {
 			Repository: mockRepo,
 		}
 
-		id := uuid.New().String()
+		id, speciesId := uuid.New().String(), "palm"
 
-		estRep := repository.GetEstateByIdOutput{
-			Count:  5,
-			Max:    11,
-			Min:    1,
-			Median: 0,
-		}
-		median := 2.
+		mockRepo.EXPECT().GetSpeciesById(ec.Request().Context(), repository.GetSpeciesByIdInput{
+			Id: speciesId,
+		}).Return(repository.GetSpeciesByIdOutput{Species: repository.Species{Id: speciesId, Name: "Oil Palm", MaxHeight: 10}}, nil)
 
-		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
-			Id: id,
-		}).Return(estRep, nil)
-		mockRepo.EXPECT().GetHeightEstateTrees(ec.Request().Context(), repository.GetHeightEstateTreesInput{
-			EstateId: id,
-		}).Return(repository.GetHeightEstateTreesOutput{
-			Heights: []int{3, 2, 1, 11, 2},
-		}, nil)
-		mockRepo.EXPECT().StoreMedianEstate(ec.Request().Context(), repository.StoreMedianEstateInput{
-			EstateId: id,
-			Median:   float64(median),
-		}).Return(nil)
+		err := server.PostEstateIdTree(ec, id)
 
-		err := server.GetEstateIdStats(ec, id)
+		resp := readJsonResult(t, resRecorder.Result())
 
-		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrSpeciesHeightBuilder("Oil Palm", 10).Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when species missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree", strings.NewReader("{\"x\": 2, \"y\": 1, \"height\": 11, \"species_id\": \"unknown\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetSpeciesById(ec.Request().Context(), repository.GetSpeciesByIdInput{
+			Id: "unknown",
+		}).Return(repository.GetSpeciesByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PostEstateIdTree(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("species").Error(), resp["message"])
+	})
+}
+
+func TestGetEstateIdStats(t *testing.T) {
+	t.Run("Return 200 when median exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		estRep := repository.GetEstateByIdOutput{
+			Count:  5,
+			Max:    11,
+			Min:    1,
+			Median: sql.NullFloat64{Float64: 3, Valid: true},
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(estRep, nil)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{})
+
+		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, estRep.Count, resp.Count)
+		assert.Equal(t, estRep.Max, resp.Max)
+		assert.Equal(t, estRep.Median.Float64, resp.Median)
+		assert.Equal(t, estRep.Min, resp.Min)
+	})
+
+	t.Run("Return 200 when median is not exist and data is odd", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		estRep := repository.GetEstateByIdOutput{
+			Count:       5,
+			Max:         11,
+			Min:         1,
+			TreeVersion: 7,
+		}
+		median := 2.
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(estRep, nil)
+		mockRepo.EXPECT().GetHeightEstateTrees(ec.Request().Context(), repository.GetHeightEstateTreesInput{
+			EstateId: id,
+		}).Return(repository.GetHeightEstateTreesOutput{
+			Heights: []int{3, 2, 1, 11, 2},
+		}, nil)
+		mockRepo.EXPECT().StoreMedianEstate(ec.Request().Context(), repository.StoreMedianEstateInput{
+			EstateId:    id,
+			Median:      float64(median),
+			TreeVersion: estRep.TreeVersion,
+		}).Return(nil)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{})
+
+		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
 
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, resRecorder.Code)
@@ -1215,12 +1620,11 @@ func TestGetEstateIdStats(t *testing.T) {
 		id := uuid.New().String()
 
 		estRep := repository.GetEstateByIdOutput{
-			Count:  8,
-			Max:    11,
-			Min:    1,
-			Median: 0,
+			Count: 8,
+			Max:   11,
+			Min:   1,
 		}
-		median := 5.5
+		median := 3.5
 
 		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
 			Id: id,
@@ -1231,11 +1635,12 @@ func TestGetEstateIdStats(t *testing.T) {
 			Heights: []int{3, 2, 1, 11, 2, 4, 7, 9},
 		}, nil)
 		mockRepo.EXPECT().StoreMedianEstate(ec.Request().Context(), repository.StoreMedianEstateInput{
-			EstateId: id,
-			Median:   float64(median),
+			EstateId:    id,
+			Median:      float64(median),
+			TreeVersion: estRep.TreeVersion,
 		}).Return(nil)
 
-		err := server.GetEstateIdStats(ec, id)
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{})
 
 		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
 
@@ -1247,7 +1652,7 @@ func TestGetEstateIdStats(t *testing.T) {
 		assert.Equal(t, estRep.Min, resp.Min)
 	})
 
-	t.Run("Return 500 when median is not exist and get height trees error", func(t *testing.T) {
+	t.Run("Return 200 when median is not exist and data has two trees", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -1265,13 +1670,11 @@ func TestGetEstateIdStats(t *testing.T) {
 		id := uuid.New().String()
 
 		estRep := repository.GetEstateByIdOutput{
-			Count:  8,
-			Max:    11,
-			Min:    1,
-			Median: 0,
+			Count: 2,
+			Max:   11,
+			Min:   4,
 		}
-
-		errAny := errors.New("any error")
+		median := 7.5
 
 		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
 			Id: id,
@@ -1279,19 +1682,24 @@ func TestGetEstateIdStats(t *testing.T) {
 		mockRepo.EXPECT().GetHeightEstateTrees(ec.Request().Context(), repository.GetHeightEstateTreesInput{
 			EstateId: id,
 		}).Return(repository.GetHeightEstateTreesOutput{
-			Heights: []int{3, 2, 1, 11, 2, 4, 7, 9},
-		}, errAny)
+			Heights: []int{11, 4},
+		}, nil)
+		mockRepo.EXPECT().StoreMedianEstate(ec.Request().Context(), repository.StoreMedianEstateInput{
+			EstateId:    id,
+			Median:      median,
+			TreeVersion: estRep.TreeVersion,
+		}).Return(nil)
 
-		err := server.GetEstateIdStats(ec, id)
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{})
 
-		resp := readJsonResult(t, resRecorder.Result())
+		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
 
 		assert.Nil(t, err)
-		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
-		assert.Equal(t, errAny.Error(), resp["message"])
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, median, resp.Median)
 	})
 
-	t.Run("Return 500 when median is not exist and get estate error", func(t *testing.T) {
+	t.Run("Return 200 when median is not exist and estate has no tree", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -1308,29 +1716,65 @@ func TestGetEstateIdStats(t *testing.T) {
 
 		id := uuid.New().String()
 
-		estRep := repository.GetEstateByIdOutput{
-			Count:  8,
-			Max:    11,
-			Min:    1,
-			Median: 0,
+		estRep := repository.GetEstateByIdOutput{}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(estRep, nil)
+		mockRepo.EXPECT().GetHeightEstateTrees(ec.Request().Context(), repository.GetHeightEstateTreesInput{
+			EstateId: id,
+		}).Return(repository.GetHeightEstateTreesOutput{}, nil)
+		mockRepo.EXPECT().StoreMedianEstate(ec.Request().Context(), repository.StoreMedianEstateInput{
+			EstateId:    id,
+			Median:      0,
+			TreeVersion: 0,
+		}).Return(nil)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{})
+
+		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 0, resp.Count)
+		assert.Equal(t, 0., resp.Median)
+	})
+
+	t.Run("Return 200 when median is zero and cached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
 		}
 
-		errAny := errors.New("any error")
+		id := uuid.New().String()
+
+		estRep := repository.GetEstateByIdOutput{
+			Median: sql.NullFloat64{Float64: 0, Valid: true},
+		}
 
 		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
 			Id: id,
-		}).Return(estRep, errAny)
+		}).Return(estRep, nil)
 
-		err := server.GetEstateIdStats(ec, id)
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{})
 
-		resp := readJsonResult(t, resRecorder.Result())
+		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
 
 		assert.Nil(t, err)
-		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
-		assert.Equal(t, errAny.Error(), resp["message"])
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 0., resp.Median)
 	})
 
-	t.Run("Return 404 when median is not exist and get estate missing", func(t *testing.T) {
+	t.Run("Return 500 when median is not exist and store median error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -1348,32 +1792,41 @@ func TestGetEstateIdStats(t *testing.T) {
 		id := uuid.New().String()
 
 		estRep := repository.GetEstateByIdOutput{
-			Count:  8,
-			Max:    11,
-			Min:    1,
-			Median: 0,
+			Count: 3,
+			Max:   11,
+			Min:   1,
 		}
 
+		errAny := errors.New("any error")
+
 		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
 			Id: id,
-		}).Return(estRep, sql.ErrNoRows)
+		}).Return(estRep, nil)
+		mockRepo.EXPECT().GetHeightEstateTrees(ec.Request().Context(), repository.GetHeightEstateTreesInput{
+			EstateId: id,
+		}).Return(repository.GetHeightEstateTreesOutput{
+			Heights: []int{1, 11, 5},
+		}, nil)
+		mockRepo.EXPECT().StoreMedianEstate(ec.Request().Context(), repository.StoreMedianEstateInput{
+			EstateId:    id,
+			Median:      5,
+			TreeVersion: estRep.TreeVersion,
+		}).Return(errAny)
 
-		err := server.GetEstateIdStats(ec, id)
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{})
 
 		resp := readJsonResult(t, resRecorder.Result())
 
 		assert.Nil(t, err)
-		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
-		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
 	})
-}
 
-func TestGetEstateIdDronePlan(t *testing.T) {
-	t.Run("Return 200", func(t *testing.T) {
+	t.Run("Return 500 when median is not exist and get height trees error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats", nil)
 		resRecorder := httptest.NewRecorder()
 
 		ec := echo.New().NewContext(req, resRecorder)
@@ -1387,27 +1840,36 @@ func TestGetEstateIdDronePlan(t *testing.T) {
 		id := uuid.New().String()
 
 		estRep := repository.GetEstateByIdOutput{
-			DroneDistance: 15,
+			Count: 8,
+			Max:   11,
+			Min:   1,
 		}
 
+		errAny := errors.New("any error")
+
 		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
 			Id: id,
 		}).Return(estRep, nil)
+		mockRepo.EXPECT().GetHeightEstateTrees(ec.Request().Context(), repository.GetHeightEstateTreesInput{
+			EstateId: id,
+		}).Return(repository.GetHeightEstateTreesOutput{
+			Heights: []int{3, 2, 1, 11, 2, 4, 7, 9},
+		}, errAny)
 
-		err := server.GetEstateIdDronePlan(ec, id)
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{})
 
-		resp := readJson[generated.EstateDronePlanResponse](t, resRecorder.Result())
+		resp := readJsonResult(t, resRecorder.Result())
 
 		assert.Nil(t, err)
-		assert.Equal(t, http.StatusOK, resRecorder.Code)
-		assert.Equal(t, estRep.DroneDistance, resp.Distance)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
 	})
 
-	t.Run("Return 500 when get estate error", func(t *testing.T) {
+	t.Run("Return 500 when median is not exist and get estate error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats", nil)
 		resRecorder := httptest.NewRecorder()
 
 		ec := echo.New().NewContext(req, resRecorder)
@@ -1421,10 +1883,9 @@ func TestGetEstateIdDronePlan(t *testing.T) {
 		id := uuid.New().String()
 
 		estRep := repository.GetEstateByIdOutput{
-			Count:  8,
-			Max:    11,
-			Min:    1,
-			Median: 0,
+			Count: 8,
+			Max:   11,
+			Min:   1,
 		}
 
 		errAny := errors.New("any error")
@@ -1433,7 +1894,7 @@ func TestGetEstateIdDronePlan(t *testing.T) {
 			Id: id,
 		}).Return(estRep, errAny)
 
-		err := server.GetEstateIdDronePlan(ec, id)
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{})
 
 		resp := readJsonResult(t, resRecorder.Result())
 
@@ -1446,7 +1907,7 @@ func TestGetEstateIdDronePlan(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plane", nil)
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats", nil)
 		resRecorder := httptest.NewRecorder()
 
 		ec := echo.New().NewContext(req, resRecorder)
@@ -1460,17 +1921,16 @@ func TestGetEstateIdDronePlan(t *testing.T) {
 		id := uuid.New().String()
 
 		estRep := repository.GetEstateByIdOutput{
-			Count:  8,
-			Max:    11,
-			Min:    1,
-			Median: 0,
+			Count: 8,
+			Max:   11,
+			Min:   1,
 		}
 
 		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
 			Id: id,
 		}).Return(estRep, sql.ErrNoRows)
 
-		err := server.GetEstateIdDronePlan(ec, id)
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{})
 
 		resp := readJsonResult(t, resRecorder.Result())
 
@@ -1478,4 +1938,7779 @@ func TestGetEstateIdDronePlan(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
 		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
 	})
+	t.Run("Return 200 when stats is restricted to a row", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats?row=2", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		row := 2
+
+		estRep := repository.GetEstateByIdOutput{
+			Id:     id,
+			Width:  3,
+			Length: 5,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(estRep, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     5,
+			MinY:     2,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 2, Height: 4},
+				{X: 3, Y: 2, Height: 10},
+				{X: 5, Y: 2, Height: 7},
+			},
+		}, nil)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{
+			Row: &row,
+		})
+
+		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.EstateStatResponse{
+			Count:  3,
+			Max:    10,
+			Min:    4,
+			Median: 7,
+		}, resp)
+	})
+
+	t.Run("Return 200 when stats is grouped by column inside a region", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats?min_x=2&max_x=3&min_y=1&max_y=2&group_by=column", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		minX, maxX, minY, maxY := 2, 3, 1, 2
+		groupBy := "column"
+
+		estRep := repository.GetEstateByIdOutput{
+			Id:     id,
+			Width:  3,
+			Length: 5,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(estRep, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     2,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 4},
+				{X: 2, Y: 2, Height: 10},
+			},
+		}, nil)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{
+			MinX:    &minX,
+			MaxX:    &maxX,
+			MinY:    &minY,
+			MaxY:    &maxY,
+			GroupBy: &groupBy,
+		})
+
+		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.EstateStatResponse{
+			Count:  2,
+			Max:    10,
+			Min:    4,
+			Median: 7,
+			Groups: &[]generated.EstateStatGroupResponse{
+				{Index: 2, Count: 2, Max: 10, Min: 4, Median: 7},
+				{Index: 3},
+			},
+		}, resp)
+	})
+
+	t.Run("Return 200 when stats is grouped by row", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats?group_by=row", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		groupBy := "row"
+
+		estRep := repository.GetEstateByIdOutput{
+			Id:     id,
+			Width:  2,
+			Length: 3,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(estRep, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 4},
+				{X: 3, Y: 1, Height: 8},
+				{X: 2, Y: 2, Height: 10},
+			},
+		}, nil)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{
+			GroupBy: &groupBy,
+		})
+
+		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.EstateStatResponse{
+			Count:  3,
+			Max:    10,
+			Min:    4,
+			Median: 8,
+			Groups: &[]generated.EstateStatGroupResponse{
+				{Index: 1, Count: 2, Max: 8, Min: 4, Median: 6},
+				{Index: 2, Count: 1, Max: 10, Min: 10, Median: 10},
+			},
+		}, resp)
+	})
+
+	t.Run("Return 400 when group by is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats?group_by=diagonal", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		groupBy := "diagonal"
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{
+			GroupBy: &groupBy,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidGroupBy.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when row is combined with y bound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats?row=1&min_y=1", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		row, minY := 1, 1
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 3, Length: 5}, nil)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{
+			Row:  &row,
+			MinY: &minY,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrParamConflictBuilder("row", "min_y and max_y").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when region is inverted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats?min_x=4&max_x=2", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		minX, maxX := 4, 2
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 3, Length: 5}, nil)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{
+			MinX: &minX,
+			MaxX: &maxX,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidRegion.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when column out of bound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats?column=6", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		column := 6
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 3, Length: 5}, nil)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{
+			Column: &column,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrCoordinateOutOfBound.Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get estate trees error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats?row=1", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		row := 1
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 3, Length: 5}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{}, errAny)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{
+			Row: &row,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 200 with coverage", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats?coverage=true", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		coverage := true
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 4},
+				{X: 3, Y: 2, Height: 8},
+			},
+		}, nil)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{
+			Coverage: &coverage,
+		})
+
+		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 2, resp.Count)
+		assert.Equal(t, &generated.EstateCoverageResponse{
+			TotalPlots:      6,
+			PlantedPlots:    2,
+			EmptyPlots:      4,
+			Coverage:        2. / 6,
+			LongestEmptyRun: 2,
+			Rows: []generated.EstateRowDensityResponse{
+				{Row: 1, PlantedPlots: 1, Density: 1. / 3},
+				{Row: 2, PlantedPlots: 1, Density: 1. / 3},
+			},
+		}, resp.Coverage)
+	})
+
+	t.Run("Return 200 grouped by species", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats?group_by=species", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		groupBy := "species"
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 4, SpeciesId: sql.NullString{String: "palm", Valid: true}},
+				{X: 3, Y: 1, Height: 8, SpeciesId: sql.NullString{String: "palm", Valid: true}},
+				{X: 2, Y: 2, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetAllSpecies(ec.Request().Context(), repository.GetAllSpeciesInput{}).Return(repository.GetAllSpeciesOutput{
+			Species: []repository.Species{
+				{Id: "durian", Name: "Durian"},
+				{Id: "palm", Name: "Oil Palm"},
+			},
+		}, nil)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{
+			GroupBy: &groupBy,
+		})
+
+		resp := readJson[generated.EstateStatResponse](t, resRecorder.Result())
+
+		speciesId, name := "palm", "Oil Palm"
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.EstateStatResponse{
+			Count:  3,
+			Max:    10,
+			Min:    4,
+			Median: 8,
+			SpeciesGroups: &[]generated.EstateSpeciesStatGroupResponse{
+				{SpeciesId: &speciesId, Name: &name, Count: 2, Max: 8, Min: 4, Median: 6},
+				{Count: 1, Max: 10, Min: 10, Median: 10},
+			},
+		}, resp)
+	})
+
+	t.Run("Return 500 when get all species error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/stats?group_by=species", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		groupBy := "species"
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 4, SpeciesId: sql.NullString{String: "palm", Valid: true}},
+				{X: 3, Y: 1, Height: 8, SpeciesId: sql.NullString{String: "palm", Valid: true}},
+				{X: 2, Y: 2, Height: 10},
+			},
+		}, nil)
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetAllSpecies(ec.Request().Context(), repository.GetAllSpeciesInput{}).Return(repository.GetAllSpeciesOutput{}, errAny)
+
+		err := server.GetEstateIdStats(ec, id, generated.GetEstateIdStatsParams{
+			GroupBy: &groupBy,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestGetEstateIdDronePlan(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		estRep := repository.GetEstateByIdOutput{
+			Length:        3,
+			Width:         1,
+			DroneDistance: 42,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(estRep, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), repository.GetEstateObstaclesInput{
+			EstateId: id,
+		}).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), repository.GetEstateElevationsInput{
+			EstateId: id,
+		}).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{})
+
+		resp := readJson[generated.EstateDronePlanResponse](t, resRecorder.Result())
+
+		// The tree in the middle of the estate takes the drone 10m up and down
+		expCost := defaultDroneProfile.cost(20, 11, 11)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, estRep.DroneDistance, resp.Distance)
+		assert.InDelta(t, expCost.time, resp.FlightTime, 1e-9)
+		assert.InDelta(t, expCost.energy, resp.Energy, 1e-9)
+		assert.Nil(t, resp.Rest)
+	})
+
+	t.Run("Return 200 when the drone climbs over obstacle", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		estRep := repository.GetEstateByIdOutput{
+			Id:            id,
+			Width:         1,
+			Length:        3,
+			DroneDistance: 42,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(estRep, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), repository.GetEstateObstaclesInput{
+			EstateId: id,
+		}).Return(repository.GetEstateObstaclesOutput{
+			Obstacles: []repository.EstateObstacle{
+				{X: 3, Y: 1, Height: 20},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), repository.GetEstateElevationsInput{
+			EstateId: id,
+		}).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{})
+
+		resp := readJson[generated.EstateDronePlanResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 62, resp.Distance)
+	})
+
+	t.Run("Return 200 ferrying from and back to the home", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		estRep := repository.GetEstateByIdOutput{
+			Id:            id,
+			Width:         1,
+			Length:        3,
+			DroneDistance: 42,
+			HomeX:         sql.NullInt64{Int64: 0, Valid: true},
+			HomeY:         sql.NullInt64{Int64: 1, Valid: true},
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(estRep, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), repository.GetEstateObstaclesInput{
+			EstateId: id,
+		}).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), repository.GetEstateElevationsInput{
+			EstateId: id,
+		}).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{})
+
+		resp := readJson[generated.EstateDronePlanResponse](t, resRecorder.Result())
+
+		// 42 over the estate + 10 ferrying out + 20 and the tree on the way back
+		expCost := defaultDroneProfile.cost(60, 21, 21)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 102, resp.Distance)
+		assert.InDelta(t, expCost.energy, resp.Energy, 1e-9)
+	})
+
+	t.Run("Return 200 comparing the constant altitude with the canopy following", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		mode := flightModeConstantAltitude
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Length: 5, Width: 1, Max: 10, DroneDistance: 82}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 10},
+				{X: 4, Y: 1, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{
+			Mode: &mode,
+		})
+
+		resp := readJson[generated.EstateDronePlanResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 62, resp.Distance)
+		assert.Equal(t, mode, resp.Mode)
+		assert.Equal(t, 82, resp.Comparison.CanopyFollowing.Distance)
+		assert.InDelta(t, defaultDroneProfile.cost(40, 21, 21).energy, resp.Comparison.CanopyFollowing.Energy, 1e-9)
+		assert.Equal(t, 62, resp.Comparison.ConstantAltitude.Distance)
+		assert.InDelta(t, defaultDroneProfile.cost(40, 11, 11).energy, resp.Comparison.ConstantAltitude.Energy, 1e-9)
+		assert.InDelta(t, defaultDroneProfile.cost(40, 11, 11).time, resp.FlightTime, 1e-9)
+	})
+
+	t.Run("Return 200 covering the estate along the middle row of the swath", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		mode := flightModeCoverage
+		swath := 3
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Length: 2, Width: 3, Max: 10, DroneDistance: 80}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 10},
+				{X: 2, Y: 3, Height: 4},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{
+			Mode:  &mode,
+			Swath: &swath,
+		})
+
+		resp := readJson[generated.EstateDronePlanResponse](t, resRecorder.Result())
+
+		// Row 2 only, above the tree of (1, 1) and then above the tree of (2, 3)
+		expCost := defaultDroneProfile.cost(10, 11, 11)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 32, resp.Distance)
+		assert.Equal(t, mode, resp.Mode)
+		assert.InDelta(t, expCost.energy, resp.Energy, 1e-9)
+		assert.Equal(t, 80, resp.Comparison.CanopyFollowing.Distance)
+		assert.Equal(t, 72, resp.Comparison.ConstantAltitude.Distance)
+		assert.Equal(t, 32, resp.Comparison.Coverage.Distance)
+	})
+
+	t.Run("Return 400 when swath is given without coverage", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		swath := 3
+
+		err := server.GetEstateIdDronePlan(ec, uuid.New().String(), generated.GetEstateIdDronePlanParams{
+			Swath: &swath,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrParamConflictBuilder("swath", flightModeCanopyFollowing).Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when swath is zero", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mode := flightModeCoverage
+		swath := 0
+
+		err := server.GetEstateIdDronePlan(ec, uuid.New().String(), generated.GetEstateIdDronePlanParams{
+			Mode:  &mode,
+			Swath: &swath,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("swath").Error(), resp["message"])
+	})
+
+	t.Run("Return 200 with rest point when the battery runs out", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		maxEnergy := 1.0
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Length: 3, Width: 1, Max: 10, DroneDistance: 42}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 10},
+			},
+		}, nil)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{
+			MaxEnergy: &maxEnergy,
+		})
+
+		resp := readJson[generated.EstateDronePlanResponse](t, resRecorder.Result())
+
+		// Landing right after the tree spares the last 10m of cruise
+		expRestCost := defaultDroneProfile.cost(10, 11, 11)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 42, resp.Distance)
+		assert.Equal(t, 2, resp.Rest.X)
+		assert.Equal(t, 1, resp.Rest.Y)
+		assert.Equal(t, 32, resp.Rest.Distance)
+		assert.InDelta(t, expRestCost.energy, resp.Rest.Energy, 1e-9)
+		// Going back home after the tree takes more than the battery
+		assert.Nil(t, resp.Sorties)
+	})
+
+	t.Run("Return 200 with sorties swapping the battery at home", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		maxDistance := 60
+		reserve := 0.2
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{
+			Length:        5,
+			Width:         1,
+			DroneDistance: 42,
+			HomeX:         sql.NullInt64{Int64: 3, Valid: true},
+			HomeY:         sql.NullInt64{Int64: 1, Valid: true},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{}, nil)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{
+			MaxDistance: &maxDistance,
+			Reserve:     &reserve,
+		})
+
+		resp := readJson[generated.EstateDronePlanResponse](t, resRecorder.Result())
+
+		sortieCost := defaultDroneProfile.cost(40, 1, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 82, resp.Distance)
+		assert.NotNil(t, resp.Rest)
+		assert.Equal(t, []generated.EstateSortieResponse{
+			{ResumeX: 1, ResumeY: 1, StopX: 3, StopY: 1, Distance: 42, FlightTime: sortieCost.time, Energy: sortieCost.energy},
+			{ResumeX: 3, ResumeY: 1, StopX: 5, StopY: 1, Distance: 42, FlightTime: sortieCost.time, Energy: sortieCost.energy},
+		}, *resp.Sorties)
+	})
+
+	t.Run("Return 400 when reserve is out of range", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		reserve := 1.0
+
+		err := server.GetEstateIdDronePlan(ec, uuid.New().String(), generated.GetEstateIdDronePlanParams{
+			Reserve: &reserve,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidReserve.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when max energy is not positive", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		maxEnergy := 0.0
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{
+			MaxEnergy: &maxEnergy,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("max_energy").Error(), resp["message"])
+	})
+
+	t.Run("Return 200 with the registered drone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		droneId := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Length: 3, Width: 1, Max: 10, DroneDistance: 42}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), repository.GetDroneByIdInput{
+			Id: droneId,
+		}).Return(repository.GetDroneByIdOutput{
+			Drone: repository.Drone{Id: droneId, MaxRange: 41, MaxAltitude: 120, CruiseSpeed: 12, ClimbRate: 4, BatteryCapacity: 90},
+		}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 10},
+			},
+		}, nil)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{
+			Drone: &droneId,
+		})
+
+		resp := readJson[generated.EstateDronePlanResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 42, resp.Distance)
+		assert.InDelta(t, 20.0/12+11.0/4+11.0/5, resp.FlightTime, 1e-9)
+		assert.Equal(t, 2, resp.Rest.X)
+		assert.Equal(t, 32, resp.Rest.Distance)
+	})
+
+	t.Run("Return 400 when the drone would fly above the ceiling", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Length: 3, Width: 1, Max: 10, Ceiling: 10, DroneDistance: 42}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 10},
+			},
+		}, nil)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{})
+
+		resp := readJson[generated.CeilingErrorResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, generated.CeilingErrorResponse{
+			Message: ErrCeilingExceeded.Error(),
+			Ceiling: 10,
+			Plots: []generated.CeilingViolationResponse{
+				{X: 2, Y: 1, Altitude: 11},
+			},
+		}, resp)
+	})
+
+	t.Run("Return 400 when the registered drone cannot fly that high", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		droneId := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Length: 3, Width: 1, Max: 10, Ceiling: 120, DroneDistance: 42}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), repository.GetDroneByIdInput{
+			Id: droneId,
+		}).Return(repository.GetDroneByIdOutput{
+			Drone: repository.Drone{Id: droneId, Model: "X1", MaxRange: 1000, MaxAltitude: 8, CruiseSpeed: 10, ClimbRate: 3, BatteryCapacity: 100},
+		}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 10},
+			},
+		}, nil)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{
+			Drone: &droneId,
+		})
+
+		resp := readJson[generated.CeilingErrorResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, 8, resp.Ceiling)
+		assert.Equal(t, []generated.CeilingViolationResponse{{X: 2, Y: 1, Altitude: 11}}, resp.Plots)
+	})
+
+	t.Run("Return 404 when drone missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		droneId := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Length: 3, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), gomock.Any()).Return(repository.GetDroneByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{
+			Drone: &droneId,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("drone").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when mode is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		mode := "hover"
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{
+			Mode: &mode,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidFlightMode.Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get obstacles error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 1, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, errAny)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get estate error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		estRep := repository.GetEstateByIdOutput{
+			Count: 8,
+			Max:   11,
+			Min:   1,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(estRep, errAny)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when median is not exist and get estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plane", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		estRep := repository.GetEstateByIdOutput{
+			Count: 8,
+			Max:   11,
+			Min:   1,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(estRep, sql.ErrNoRows)
+
+		err := server.GetEstateIdDronePlan(ec, id, generated.GetEstateIdDronePlanParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestPostEstateIdDronePlanSnapshot(t *testing.T) {
+	t.Run("Return 201 with the frozen plan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/drone-plan:snapshot", strings.NewReader("{}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		trees := []repository.EstateTree{
+			{X: 1, Y: 1, Height: 10},
+			{X: 2, Y: 1, Height: 5},
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 2, Width: 1, Max: 10, Ceiling: 120}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     2,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{Trees: trees}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		var stored repository.DronePlanSnapshot
+		mockRepo.EXPECT().CreateDronePlanSnapshot(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ context.Context, input repository.CreateDronePlanSnapshotInput) (repository.CreateDronePlanSnapshotOutput, error) {
+			stored = input.DronePlanSnapshot
+
+			return repository.CreateDronePlanSnapshotOutput{Version: 2, CreatedAt: createdAt}, nil
+		})
+
+		err := server.PostEstateIdDronePlanSnapshot(ec, id)
+
+		resp := readJson[generated.DronePlanSnapshotResponse](t, resRecorder.Result())
+
+		expWaypoints := []repository.PlanWaypoint{
+			{X: 1, Y: 1, Altitude: 11},
+			{X: 2, Y: 1, Altitude: 6},
+		}
+		expCost := defaultDroneProfile.cost(10, 11, 11)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+		assert.Equal(t, id, stored.EstateId)
+		assert.Equal(t, flightModeCanopyFollowing, stored.Mode)
+		assert.Equal(t, hashTrees(trees), stored.TreeHash)
+		assert.Equal(t, expWaypoints, stored.Waypoints)
+		assert.Equal(t, 2, resp.Version)
+		assert.Equal(t, expCost.distance, resp.Distance)
+		assert.Equal(t, stored.TreeHash, resp.TreeHash)
+		assert.Equal(t, stored.TreeHash, resp.CurrentTreeHash)
+		assert.False(t, resp.Stale)
+		assert.True(t, createdAt.Equal(resp.CreatedAt))
+		assert.Equal(t, []generated.PlanWaypointResponse{
+			{X: 1, Y: 1, Altitude: 11},
+			{X: 2, Y: 1, Altitude: 6},
+		}, resp.Waypoints)
+	})
+
+	t.Run("Return 400 when the plan flies above the ceiling", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/drone-plan:snapshot", strings.NewReader("{}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		trees := []repository.EstateTree{
+			{X: 1, Y: 1, Height: 10},
+			{X: 2, Y: 1, Height: 5},
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 2, Width: 1, Max: 10, Ceiling: 8}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     2,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{Trees: trees}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdDronePlanSnapshot(ec, id)
+
+		resp := readJson[generated.CeilingErrorResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrCeilingExceeded.Error(), resp.Message)
+		assert.Equal(t, []generated.CeilingViolationResponse{{X: 1, Y: 1, Altitude: 11}}, resp.Plots)
+	})
+
+	t.Run("Return 400 when mode is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/drone-plan:snapshot", strings.NewReader("{\"mode\": \"hover\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdDronePlanSnapshot(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidFlightMode.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when swath is given without coverage mode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/drone-plan:snapshot", strings.NewReader("{\"swath\": 2}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdDronePlanSnapshot(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrParamConflictBuilder("swath", flightModeCanopyFollowing).Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when create snapshot error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/drone-plan:snapshot", strings.NewReader("{}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		trees := []repository.EstateTree{
+			{X: 1, Y: 1, Height: 10},
+			{X: 2, Y: 1, Height: 5},
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 2, Width: 1, Max: 10, Ceiling: 120}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     2,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{Trees: trees}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().CreateDronePlanSnapshot(ec.Request().Context(), gomock.Any()).Return(repository.CreateDronePlanSnapshotOutput{}, errAny)
+
+		err := server.PostEstateIdDronePlanSnapshot(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/drone-plan:snapshot", strings.NewReader("{}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PostEstateIdDronePlanSnapshot(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestGetEstateIdDronePlanSnapshot(t *testing.T) {
+	t.Run("Return 200 with the latest plan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan:snapshot", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		trees := []repository.EstateTree{
+			{X: 1, Y: 1, Height: 10},
+			{X: 2, Y: 1, Height: 5},
+		}
+		snapshot := repository.DronePlanSnapshot{
+			EstateId: id,
+			Version:  3,
+			Mode:     flightModeCanopyFollowing,
+			TreeHash: hashTrees(trees),
+			Distance: 32,
+			Waypoints: []repository.PlanWaypoint{
+				{X: 1, Y: 1, Altitude: 11},
+				{X: 2, Y: 1, Altitude: 6},
+			},
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 2, Width: 1}, nil)
+		mockRepo.EXPECT().GetDronePlanSnapshot(ec.Request().Context(), repository.GetDronePlanSnapshotInput{
+			EstateId: id,
+			Version:  0,
+		}).Return(repository.GetDronePlanSnapshotOutput{DronePlanSnapshot: snapshot}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     2,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{Trees: trees}, nil)
+
+		err := server.GetEstateIdDronePlanSnapshot(ec, id, generated.GetEstateIdDronePlanSnapshotParams{})
+
+		resp := readJson[generated.DronePlanSnapshotResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 3, resp.Version)
+		assert.Equal(t, 32, resp.Distance)
+		assert.False(t, resp.Stale)
+		assert.Len(t, resp.Waypoints, 2)
+	})
+
+	t.Run("Return 200 with a stale plan once the trees changed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan:snapshot", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, version := uuid.New().String(), 1
+		trees := []repository.EstateTree{
+			{X: 1, Y: 1, Height: 10},
+			{X: 2, Y: 1, Height: 5},
+		}
+		snapshot := repository.DronePlanSnapshot{
+			EstateId: id,
+			Version:  1,
+			Mode:     flightModeCanopyFollowing,
+			TreeHash: hashTrees(trees[:1]),
+			Distance: 32,
+			Waypoints: []repository.PlanWaypoint{
+				{X: 1, Y: 1, Altitude: 11},
+				{X: 2, Y: 1, Altitude: 6},
+			},
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 2, Width: 1}, nil)
+		mockRepo.EXPECT().GetDronePlanSnapshot(ec.Request().Context(), repository.GetDronePlanSnapshotInput{
+			EstateId: id,
+			Version:  1,
+		}).Return(repository.GetDronePlanSnapshotOutput{DronePlanSnapshot: snapshot}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     2,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{Trees: trees}, nil)
+
+		err := server.GetEstateIdDronePlanSnapshot(ec, id, generated.GetEstateIdDronePlanSnapshotParams{Version: &version})
+
+		resp := readJson[generated.DronePlanSnapshotResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 1, resp.Version)
+		assert.True(t, resp.Stale)
+		assert.Equal(t, snapshot.TreeHash, resp.TreeHash)
+		assert.Equal(t, hashTrees(trees), resp.CurrentTreeHash)
+	})
+
+	t.Run("Return 400 when version is zero", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan:snapshot", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		version := 0
+
+		err := server.GetEstateIdDronePlanSnapshot(ec, uuid.New().String(), generated.GetEstateIdDronePlanSnapshotParams{Version: &version})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("version").Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when snapshot missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan:snapshot", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Length: 2, Width: 1}, nil)
+		mockRepo.EXPECT().GetDronePlanSnapshot(ec.Request().Context(), gomock.Any()).Return(repository.GetDronePlanSnapshotOutput{}, sql.ErrNoRows)
+
+		err := server.GetEstateIdDronePlanSnapshot(ec, uuid.New().String(), generated.GetEstateIdDronePlanSnapshotParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("snapshot").Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan:snapshot", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetEstateIdDronePlanSnapshot(ec, uuid.New().String(), generated.GetEstateIdDronePlanSnapshotParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get snapshot error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/drone-plan:snapshot", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Length: 2, Width: 1}, nil)
+		mockRepo.EXPECT().GetDronePlanSnapshot(ec.Request().Context(), gomock.Any()).Return(repository.GetDronePlanSnapshotOutput{}, errAny)
+
+		err := server.GetEstateIdDronePlanSnapshot(ec, uuid.New().String(), generated.GetEstateIdDronePlanSnapshotParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestPostEstateIdInspectionPlan(t *testing.T) {
+	t.Run("Return 200 with the trees above the height", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/inspection-plan", strings.NewReader("{\"min_height\": 25}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 5, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     5,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{Id: "tree-2", X: 2, Y: 1, Height: 10},
+				{Id: "tree-4", X: 4, Y: 1, Height: 26},
+				{Id: "tree-5", X: 5, Y: 1, Height: 27},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdInspectionPlan(ec, id)
+
+		resp := readJson[generated.InspectionPlanResponse](t, resRecorder.Result())
+
+		tree4, tree5 := "tree-4", "tree-5"
+		// 27 takeoff + (10 + 1) + 28 landing
+		expCost := defaultDroneProfile.cost(10, 28, 28)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 66, resp.Distance)
+		assert.InDelta(t, expCost.energy, resp.Energy, 1e-9)
+		assert.Equal(t, []generated.InspectionWaypointResponse{
+			{X: 4, Y: 1, Altitude: 27, TreeId: &tree4},
+			{X: 5, Y: 1, Altitude: 28, TreeId: &tree5},
+		}, resp.Waypoints)
+		assert.Empty(t, resp.Unreachable)
+	})
+
+	t.Run("Return 200 with the selected trees from and back to the home", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/inspection-plan", strings.NewReader("{\"tree_ids\": [\"tree-2\", \"tree-5\"]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 5, Width: 1, HomeX: sql.NullInt64{Int64: 0, Valid: true}, HomeY: sql.NullInt64{Int64: 1, Valid: true}}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     5,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{Id: "tree-2", X: 2, Y: 1, Height: 10},
+				{Id: "tree-4", X: 4, Y: 1, Height: 26},
+				{Id: "tree-5", X: 5, Y: 1, Height: 27},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdInspectionPlan(ec, id)
+
+		resp := readJson[generated.InspectionPlanResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Len(t, resp.Waypoints, 11)
+		assert.Equal(t, 0, resp.Waypoints[0].X)
+		assert.Equal(t, "tree-2", *resp.Waypoints[2].TreeId)
+		assert.Equal(t, "tree-5", *resp.Waypoints[5].TreeId)
+		assert.Equal(t, 0, resp.Waypoints[10].X)
+		assert.Nil(t, resp.Waypoints[10].TreeId)
+	})
+
+	t.Run("Return 200 with no tree matching", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/inspection-plan", strings.NewReader("{\"max_height\": 5}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 5, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     5,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{Id: "tree-2", X: 2, Y: 1, Height: 10},
+				{Id: "tree-4", X: 4, Y: 1, Height: 26},
+				{Id: "tree-5", X: 5, Y: 1, Height: 27},
+			},
+		}, nil)
+
+		err := server.PostEstateIdInspectionPlan(ec, id)
+
+		resp := readJson[generated.InspectionPlanResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.InspectionPlanResponse{Unreachable: []string{}, Waypoints: []generated.InspectionWaypointResponse{}}, resp)
+	})
+
+	t.Run("Return 200 starting above the first selected tree without a home", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/inspection-plan", strings.NewReader("{\"min_height\": 10}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     3,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{Id: "tree-31", X: 3, Y: 1, Height: 10},
+				{Id: "tree-12", X: 1, Y: 2, Height: 10},
+				{Id: "tree-33", X: 3, Y: 3, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdInspectionPlan(ec, id)
+
+		resp := readJson[generated.InspectionPlanResponse](t, resRecorder.Result())
+
+		var visited []string
+		for _, wp := range resp.Waypoints {
+			if wp.TreeId != nil {
+				visited = append(visited, *wp.TreeId)
+			}
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, "tree-31", *resp.Waypoints[0].TreeId)
+		assert.Equal(t, []string{"tree-31", "tree-33", "tree-12"}, visited)
+		assert.Empty(t, resp.Unreachable)
+	})
+
+	t.Run("Return 200 with the trees the drone cannot reach", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/inspection-plan", strings.NewReader("{\"tree_ids\": [\"tree-1\", \"tree-2\", \"tree-3\"]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{Id: "tree-1", X: 1, Y: 1, Height: 10},
+				{Id: "tree-2", X: 2, Y: 1, Height: 10},
+				{Id: "tree-3", X: 3, Y: 1, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{
+			Obstacles: []repository.EstateObstacle{
+				{X: 2, Y: 1, NoFly: true},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdInspectionPlan(ec, id)
+
+		resp := readJson[generated.InspectionPlanResponse](t, resRecorder.Result())
+
+		tree1 := "tree-1"
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, []generated.InspectionWaypointResponse{
+			{X: 1, Y: 1, Altitude: 11, TreeId: &tree1},
+		}, resp.Waypoints)
+		assert.Equal(t, []string{"tree-2", "tree-3"}, resp.Unreachable)
+	})
+
+	t.Run("Return 404 when a tree is not in the estate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/inspection-plan", strings.NewReader("{\"tree_ids\": [\"tree-4\", \"tree-9\"]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 5, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     5,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{Id: "tree-2", X: 2, Y: 1, Height: 10},
+				{Id: "tree-4", X: 4, Y: 1, Height: 26},
+				{Id: "tree-5", X: 5, Y: 1, Height: 27},
+			},
+		}, nil)
+
+		err := server.PostEstateIdInspectionPlan(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("tree").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when nothing is selected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/inspection-plan", strings.NewReader("{}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdInspectionPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInspectionEmpty.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when min height is greater than max height", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/inspection-plan", strings.NewReader("{\"min_height\": 20, \"max_height\": 10}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdInspectionPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidHeightFilter.Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get estate trees error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/inspection-plan", strings.NewReader("{\"min_height\": 25}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Length: 5, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{}, errAny)
+
+		err := server.PostEstateIdInspectionPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/inspection-plan", strings.NewReader("{\"min_height\": 25}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PostEstateIdInspectionPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestPostEstateIdSprayPlan(t *testing.T) {
+	t.Run("Return 200 with the refills", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/spray-plan", strings.NewReader("{\"rate\": 0.5, \"tank_capacity\": 12}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 10},
+				{X: 3, Y: 1, Height: 20},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdSprayPlan(ec, id)
+
+		resp := readJson[generated.SprayPlanResponse](t, resRecorder.Result())
+
+		expCost := defaultDroneProfile.cost(60, 72, 72)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 15.0, resp.Chemical)
+		assert.Equal(t, 204, resp.Distance)
+		assert.InDelta(t, expCost.energy, resp.Energy, 1e-9)
+		assert.Equal(t, []generated.SprayRefillResponse{{X: 3, Y: 1, Sprayed: 5}}, resp.Refills)
+		assert.Empty(t, resp.Unreachable)
+	})
+
+	t.Run("Return 200 with the trees the drone cannot reach", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/spray-plan", strings.NewReader("{\"rate\": 0.5, \"tank_capacity\": 12}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 10},
+				{X: 3, Y: 1, Height: 20},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{
+			Obstacles: []repository.EstateObstacle{
+				{X: 2, Y: 1, NoFly: true},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdSprayPlan(ec, id)
+
+		resp := readJson[generated.SprayPlanResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 5.0, resp.Chemical)
+		assert.Equal(t, 22, resp.Distance)
+		assert.Empty(t, resp.Refills)
+		assert.Equal(t, []generated.PlotResponse{{X: 3, Y: 1}}, resp.Unreachable)
+	})
+
+	t.Run("Return 400 when a tree needs more than the tank holds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/spray-plan", strings.NewReader("{\"rate\": 0.5, \"tank_capacity\": 8}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 10},
+				{X: 3, Y: 1, Height: 20},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdSprayPlan(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrDoseOverTank.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when rate is zero", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/spray-plan", strings.NewReader("{\"rate\": 0, \"tank_capacity\": 8}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdSprayPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("rate").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when tank capacity is negative", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/spray-plan", strings.NewReader("{\"rate\": 0.5, \"tank_capacity\": -1}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdSprayPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("tank_capacity").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get estate trees error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/spray-plan", strings.NewReader("{\"rate\": 0.5, \"tank_capacity\": 12}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Length: 3, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{}, errAny)
+
+		err := server.PostEstateIdSprayPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/spray-plan", strings.NewReader("{\"rate\": 0.5, \"tank_capacity\": 12}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PostEstateIdSprayPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestPostEstateIdSeedingPlan(t *testing.T) {
+	t.Run("Return 200 with the refills", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/seeding-plan", strings.NewReader("{\"seed_capacity\": 2}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 2}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 10},
+				{X: 2, Y: 2, Height: 20},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdSeedingPlan(ec, id)
+
+		resp := readJson[generated.SeedingPlanResponse](t, resRecorder.Result())
+
+		expCost := defaultDroneProfile.cost(80, 22, 22)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 4, resp.Plots)
+		assert.Equal(t, 4, resp.Seeds)
+		assert.Equal(t, expCost.distance, resp.Distance)
+		assert.InDelta(t, expCost.energy, resp.Energy, 1e-9)
+		assert.Equal(t, []generated.SeedingRefillResponse{{X: 3, Y: 2, Seeded: 2}}, resp.Refills)
+		assert.Equal(t, []generated.SeedingWaypointResponse{
+			{X: 2, Y: 1, Altitude: 1},
+			{X: 3, Y: 1, Altitude: 1},
+			{X: 3, Y: 2, Altitude: 1},
+			{X: 2, Y: 2, Altitude: 21, Transit: true},
+			{X: 1, Y: 2, Altitude: 1},
+		}, resp.Waypoints)
+		assert.Empty(t, resp.Unreachable)
+	})
+
+	t.Run("Return 200 with the empty plots the drone cannot reach", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/seeding-plan", strings.NewReader("{\"seed_capacity\": 5}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{
+			Obstacles: []repository.EstateObstacle{
+				{X: 2, Y: 1, NoFly: true},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdSeedingPlan(ec, id)
+
+		resp := readJson[generated.SeedingPlanResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 1, resp.Plots)
+		assert.Equal(t, 1, resp.Seeds)
+		assert.Equal(t, 2, resp.Distance)
+		assert.Empty(t, resp.Refills)
+		assert.Equal(t, []generated.SeedingWaypointResponse{{X: 1, Y: 1, Altitude: 1}}, resp.Waypoints)
+		assert.Equal(t, []generated.PlotResponse{{X: 3, Y: 1}}, resp.Unreachable)
+	})
+
+	t.Run("Return 200 with no waypoints when every plot has a tree", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/seeding-plan", strings.NewReader("{\"seed_capacity\": 2}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 2}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 10},
+				{X: 2, Y: 1, Height: 10},
+				{X: 3, Y: 1, Height: 10},
+				{X: 1, Y: 2, Height: 10},
+				{X: 2, Y: 2, Height: 10},
+				{X: 3, Y: 2, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdSeedingPlan(ec, id)
+
+		resp := readJson[generated.SeedingPlanResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 0, resp.Plots)
+		assert.Empty(t, resp.Refills)
+		assert.Empty(t, resp.Waypoints)
+	})
+
+	t.Run("Return 400 when a plot needs more seeds than the drone carries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/seeding-plan", strings.NewReader("{\"seed_capacity\": 2, \"seeds_per_plot\": 3}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 2}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 10},
+				{X: 2, Y: 2, Height: 20},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.PostEstateIdSeedingPlan(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrSeedsOverCapacity.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when seed capacity is zero", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/seeding-plan", strings.NewReader("{\"seed_capacity\": 0}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdSeedingPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("seed_capacity").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when seeds per plot is negative", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/seeding-plan", strings.NewReader("{\"seed_capacity\": 2, \"seeds_per_plot\": -1}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdSeedingPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("seeds_per_plot").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get estate trees error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/seeding-plan", strings.NewReader("{\"seed_capacity\": 2}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Length: 3, Width: 2}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{}, errAny)
+
+		err := server.PostEstateIdSeedingPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/seeding-plan", strings.NewReader("{\"seed_capacity\": 2}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PostEstateIdSeedingPlan(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestGetEstateIdHeatmap(t *testing.T) {
+	t.Run("Return 200 with height matrix", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/heatmap", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 5},
+				{X: 3, Y: 2, Height: 12},
+			},
+		}, nil)
+
+		err := server.GetEstateIdHeatmap(ec, id, generated.GetEstateIdHeatmapParams{})
+
+		resp := readJson[generated.EstateHeatmapResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.EstateHeatmapResponse{
+			Width:  2,
+			Length: 3,
+			Max:    12,
+			Heights: [][]int{
+				{0, 5, 0},
+				{0, 0, 12},
+			},
+		}, resp)
+	})
+
+	t.Run("Return 200 with png image", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/heatmap?format=png", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		format := "png"
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 5},
+				{X: 3, Y: 2, Height: 12},
+			},
+		}, nil)
+
+		err := server.GetEstateIdHeatmap(ec, id, generated.GetEstateIdHeatmapParams{
+			Format: &format,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, "image/png", resRecorder.Header().Get("Content-Type"))
+
+		img, err := png.Decode(resRecorder.Body)
+		assert.Nil(t, err)
+		assert.Equal(t, image.Rect(0, 0, 3*heatmapPlotPixels, 2*heatmapPlotPixels), img.Bounds())
+		assert.Equal(t, heatmapEmptyColor, color.RGBAModel.Convert(img.At(0, 0)))
+		assert.Equal(t, heatmapTallColor, color.RGBAModel.Convert(img.At(2*heatmapPlotPixels, heatmapPlotPixels)))
+	})
+
+	t.Run("Return 400 when format is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/heatmap?format=gif", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		format := "gif"
+
+		err := server.GetEstateIdHeatmap(ec, id, generated.GetEstateIdHeatmapParams{
+			Format: &format,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidHeatmapFormat.Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get estate trees error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/heatmap", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{}, errAny)
+
+		err := server.GetEstateIdHeatmap(ec, id, generated.GetEstateIdHeatmapParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/heatmap", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetEstateIdHeatmap(ec, id, generated.GetEstateIdHeatmapParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestGetEstateIdMapSvg(t *testing.T) {
+	t.Run("Return 200 with flight path", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/map.svg", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), repository.GetEstateObstaclesInput{
+			EstateId: id,
+		}).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), repository.GetEstateElevationsInput{
+			EstateId: id,
+		}).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.GetEstateIdMapSvg(ec, id, generated.GetEstateIdMapSvgParams{})
+
+		body := resRecorder.Body.String()
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, "image/svg+xml", resRecorder.Header().Get("Content-Type"))
+		assert.Contains(t, body, `points="40,40 80,40 120,40 120,80 80,80 40,80"`)
+		assert.Contains(t, body, `<title>(2, 1) 10m</title>`)
+		assert.Contains(t, body, `>11m</text>`)
+		assert.NotContains(t, body, `>rest</text>`)
+	})
+
+	t.Run("Return 200 with rest point", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/map.svg?max_distance=50", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		maxDistance := 50
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), repository.GetEstateObstaclesInput{
+			EstateId: id,
+		}).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), repository.GetEstateElevationsInput{
+			EstateId: id,
+		}).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.GetEstateIdMapSvg(ec, id, generated.GetEstateIdMapSvgParams{
+			MaxDistance: &maxDistance,
+		})
+
+		body := resRecorder.Body.String()
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Contains(t, body, `<title>rest (3, 1)</title>`)
+	})
+
+	t.Run("Return 200 with the ferries from and back to home", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/map.svg?max_distance=50", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		maxDistance := 50
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3, HomeX: sql.NullInt64{Int64: 3, Valid: true}, HomeY: sql.NullInt64{Int64: 3, Valid: true}}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+
+		err := server.GetEstateIdMapSvg(ec, id, generated.GetEstateIdMapSvgParams{
+			MaxDistance: &maxDistance,
+		})
+
+		body := resRecorder.Body.String()
+
+		// The drone ferries up from home at (3, 3) to the first row and back
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Contains(t, body, `points="120,120 120,80 80,80 40,80 40,40 80,40 120,40 120,80 80,80 40,80 80,80 120,80 120,120"`)
+		assert.Contains(t, body, `<title>rest (1, 1)</title>`)
+	})
+
+	t.Run("Return 400 when max distance is zero", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/map.svg?max_distance=0", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		maxDistance := 0
+
+		err := server.GetEstateIdMapSvg(ec, id, generated.GetEstateIdMapSvgParams{
+			MaxDistance: &maxDistance,
+		})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("max_distance").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get estate trees error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/map.svg", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{}, errAny)
+
+		err := server.GetEstateIdMapSvg(ec, id, generated.GetEstateIdMapSvgParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/map.svg", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetEstateIdMapSvg(ec, id, generated.GetEstateIdMapSvgParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestPostEstateIdObstacle(t *testing.T) {
+	t.Run("Return 201", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/obstacle", strings.NewReader(`{"x": 2, "y": 1, "height": 15, "description": "Water tower"}`))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), repository.GetEstateObstaclesInput{
+			EstateId: id,
+		}).Return(repository.GetEstateObstaclesOutput{
+			Obstacles: []repository.EstateObstacle{
+				{X: 1, Y: 1, NoFly: true},
+			},
+		}, nil)
+		mockRepo.EXPECT().CreateObstacle(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ any, input repository.CreateObstacleInput) error {
+			assert.Equal(t, id, input.EstateId)
+			assert.Equal(t, 2, input.X)
+			assert.Equal(t, 1, input.Y)
+			assert.Equal(t, 15, input.Height)
+			assert.False(t, input.NoFly)
+			assert.Equal(t, "Water tower", input.Description)
+
+			return nil
+		})
+
+		err := server.PostEstateIdObstacle(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+
+		_, err = uuid.Parse(resp["id"].(string))
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return 500 when create obstacle error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/obstacle", strings.NewReader(`{"x": 2, "y": 1, "no_fly": true}`))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, nil)
+		mockRepo.EXPECT().CreateObstacle(ec.Request().Context(), gomock.Any()).Return(errAny)
+
+		err := server.PostEstateIdObstacle(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when plot already has obstacle", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/obstacle", strings.NewReader(`{"x": 1, "y": 1, "no_fly": true}`))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{
+			Obstacles: []repository.EstateObstacle{
+				{X: 1, Y: 1, Height: 4},
+			},
+		}, nil)
+
+		err := server.PostEstateIdObstacle(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrObstacleExist.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when coordinate out of bound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/obstacle", strings.NewReader(`{"x": 4, "y": 1, "no_fly": true}`))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+
+		err := server.PostEstateIdObstacle(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrCoordinateOutOfBound.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/obstacle", strings.NewReader(`{"x": 1, "y": 1, "height": 5}`))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PostEstateIdObstacle(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when obstacle has no height and is not no-fly", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/obstacle", strings.NewReader(`{"x": 1, "y": 1, "no_fly": false}`))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdObstacle(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrObstacleEmpty.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when height is negative", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/obstacle", strings.NewReader(`{"x": 1, "y": 1, "height": -5}`))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdObstacle(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("height").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when x is zero", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/obstacle", strings.NewReader(`{"x": 0, "y": 1, "height": 5}`))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdObstacle(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("x").Error(), resp["message"])
+	})
+}
+
+func TestGetEstateIdObstacle(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/obstacle", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		obstacleId := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), repository.GetEstateObstaclesInput{
+			EstateId: id,
+		}).Return(repository.GetEstateObstaclesOutput{
+			Obstacles: []repository.EstateObstacle{
+				{Id: obstacleId, X: 1, Y: 2, NoFly: true, Description: "Shed"},
+			},
+		}, nil)
+
+		err := server.GetEstateIdObstacle(ec, id)
+
+		resp := readJson[generated.EstateObstacleListResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.EstateObstacleListResponse{
+			Obstacles: []generated.EstateObstacleResponse{
+				{Id: obstacleId, X: 1, Y: 2, NoFly: true, Description: "Shed"},
+			},
+		}, resp)
+	})
+
+	t.Run("Return 500 when get obstacles error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/obstacle", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateObstacles(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateObstaclesOutput{}, errAny)
+
+		err := server.GetEstateIdObstacle(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/obstacle", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetEstateIdObstacle(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestDeleteEstateIdObstacleObstacleId(t *testing.T) {
+	t.Run("Return 204", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodDelete, "/estate/:id/obstacle/:obstacleId", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		obstacleId := uuid.New().String()
+
+		mockRepo.EXPECT().DeleteObstacle(ec.Request().Context(), repository.DeleteObstacleInput{
+			Id:       obstacleId,
+			EstateId: id,
+		}).Return(nil)
+
+		err := server.DeleteEstateIdObstacleObstacleId(ec, id, obstacleId)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resRecorder.Code)
+	})
+
+	t.Run("Return 500 when delete obstacle error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodDelete, "/estate/:id/obstacle/:obstacleId", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().DeleteObstacle(ec.Request().Context(), gomock.Any()).Return(errAny)
+
+		err := server.DeleteEstateIdObstacleObstacleId(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when obstacle missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodDelete, "/estate/:id/obstacle/:obstacleId", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().DeleteObstacle(ec.Request().Context(), gomock.Any()).Return(sql.ErrNoRows)
+
+		err := server.DeleteEstateIdObstacleObstacleId(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("obstacle").Error(), resp["message"])
+	})
+}
+
+func TestPutEstateIdElevation(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/elevation", strings.NewReader("{\"elevations\": [[0, 5, 8]]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 1, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 2, Y: 1, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), repository.GetEstateElevationsInput{
+			EstateId: id,
+		}).Return(repository.GetEstateElevationsOutput{}, nil)
+		mockRepo.EXPECT().StoreEstateElevations(ec.Request().Context(), repository.StoreEstateElevationsInput{
+			EstateId: id,
+			Elevations: []repository.PlotElevation{
+				{X: 2, Y: 1, Elevation: 5},
+				{X: 3, Y: 1, Elevation: 8},
+			},
+			// 44 over the new terrain, 42 over the flat one
+			DroneDistFactor: 2,
+		}).Return(nil)
+
+		err := server.PutEstateIdElevation(ec, id)
+
+		resp := readJson[generated.EstateElevationResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.EstateElevationResponse{
+			Width:      1,
+			Length:     3,
+			Elevations: [][]int{{0, 5, 8}},
+		}, resp)
+	})
+
+	t.Run("Return 400 when elevations do not match the estate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/elevation", strings.NewReader("{\"elevations\": [[0, 5, 8], [0, 0, 0]]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 1, Length: 3}, nil)
+
+		err := server.PutEstateIdElevation(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrElevationDimension.Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when store elevations error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/elevation", strings.NewReader("{\"elevations\": [[0, 5, 8]]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Id: id, Width: 1, Length: 3}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateElevationsOutput{}, nil)
+		mockRepo.EXPECT().StoreEstateElevations(ec.Request().Context(), gomock.Any()).Return(errAny)
+
+		err := server.PutEstateIdElevation(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/elevation", strings.NewReader("{\"elevations\": [[0, 5, 8]]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PutEstateIdElevation(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestGetEstateIdElevation(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/elevation", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 2}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), repository.GetEstateElevationsInput{
+			EstateId: id,
+		}).Return(repository.GetEstateElevationsOutput{
+			Elevations: []repository.PlotElevation{
+				{X: 1, Y: 2, Elevation: 7},
+			},
+		}, nil)
+
+		err := server.GetEstateIdElevation(ec, id)
+
+		resp := readJson[generated.EstateElevationResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.EstateElevationResponse{
+			Width:      2,
+			Length:     2,
+			Elevations: [][]int{{0, 0}, {7, 0}},
+		}, resp)
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/elevation", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetEstateIdElevation(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestPutEstateIdHome(t *testing.T) {
+	t.Run("Return 200 with the home at the boundary", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/home", strings.NewReader("{\"x\": 0, \"y\": 2}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().StoreEstateHome(ec.Request().Context(), repository.StoreEstateHomeInput{
+			EstateId: id,
+			X:        0,
+			Y:        2,
+		}).Return(nil)
+
+		err := server.PutEstateIdHome(ec, id)
+
+		resp := readJson[generated.EstateHomeResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.EstateHomeResponse{X: 0, Y: 2}, resp)
+	})
+
+	t.Run("Return 400 when home is beyond the boundary", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/home", strings.NewReader("{\"x\": 5, \"y\": 1}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+
+		err := server.PutEstateIdHome(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrHomeOutOfBound.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when home is at the corner outside the estate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/home", strings.NewReader("{\"x\": 4, \"y\": 3}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+
+		err := server.PutEstateIdHome(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrHomeOutOfBound.Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when store home error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/home", strings.NewReader("{\"x\": 2, \"y\": 2}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3}, nil)
+		mockRepo.EXPECT().StoreEstateHome(ec.Request().Context(), gomock.Any()).Return(errAny)
+
+		err := server.PutEstateIdHome(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/home", strings.NewReader("{\"x\": 0, \"y\": 1}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PutEstateIdHome(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestPutEstateIdCeiling(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/ceiling", strings.NewReader("{\"ceiling\": 21}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3, Max: 20, Ceiling: 120}, nil)
+		mockRepo.EXPECT().StoreEstateCeiling(ec.Request().Context(), repository.StoreEstateCeilingInput{
+			EstateId: id,
+			Ceiling:  21,
+		}).Return(nil)
+
+		err := server.PutEstateIdCeiling(ec, id)
+
+		resp := readJson[generated.EstateCeilingResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.EstateCeilingResponse{Ceiling: 21}, resp)
+	})
+
+	t.Run("Return 400 when trees reach above the ceiling", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/ceiling", strings.NewReader("{\"ceiling\": 15}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3, Max: 20, Ceiling: 120}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     2,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 14},
+				{X: 3, Y: 1, Height: 15},
+				{X: 2, Y: 2, Height: 20},
+			},
+		}, nil)
+
+		err := server.PutEstateIdCeiling(ec, id)
+
+		resp := readJson[generated.CeilingErrorResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, generated.CeilingErrorResponse{
+			Message: ErrCeilingExceeded.Error(),
+			Ceiling: 15,
+			Plots: []generated.CeilingViolationResponse{
+				{X: 3, Y: 1, Altitude: 16},
+				{X: 2, Y: 2, Altitude: 21},
+			},
+		}, resp)
+	})
+
+	t.Run("Return 400 when ceiling is zero", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/ceiling", strings.NewReader("{\"ceiling\": 0}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		err := server.PutEstateIdCeiling(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("ceiling").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when store ceiling error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/ceiling", strings.NewReader("{\"ceiling\": 60}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Id: id, Width: 2, Length: 3, Max: 20}, nil)
+		mockRepo.EXPECT().StoreEstateCeiling(ec.Request().Context(), gomock.Any()).Return(errAny)
+
+		err := server.PutEstateIdCeiling(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/ceiling", strings.NewReader("{\"ceiling\": 60}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PutEstateIdCeiling(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+}
+
+func TestPostDrone(t *testing.T) {
+	t.Run("Return 201", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/drone", strings.NewReader("{\"model\": \"Surveyor X1\", \"max_range\": 5000, \"max_altitude\": 120, \"cruise_speed\": 12, \"climb_rate\": 4, \"battery_capacity\": 90}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().CreateDrone(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ any, input repository.CreateDroneInput) error {
+			assert.Equal(t, "Surveyor X1", input.Model)
+			assert.Equal(t, 5000, input.MaxRange)
+			assert.Equal(t, 90.0, input.BatteryCapacity)
+
+			return nil
+		})
+
+		err := server.PostDrone(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+
+		_, err = uuid.Parse(resp["id"].(string))
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return 400 when model is empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/drone", strings.NewReader("{\"max_range\": 5000, \"max_altitude\": 120, \"cruise_speed\": 12, \"climb_rate\": 4, \"battery_capacity\": 90}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostDrone(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrEmptyBuilder("model").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when climb rate is not positive", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/drone", strings.NewReader("{\"model\": \"Surveyor X1\", \"max_range\": 5000, \"max_altitude\": 120, \"cruise_speed\": 12, \"climb_rate\": 0, \"battery_capacity\": 90}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostDrone(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("climb_rate").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when create drone error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/drone", strings.NewReader("{\"model\": \"Surveyor X1\", \"max_range\": 5000, \"max_altitude\": 120, \"cruise_speed\": 12, \"climb_rate\": 4, \"battery_capacity\": 90}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().CreateDrone(ec.Request().Context(), gomock.Any()).Return(errAny)
+
+		err := server.PostDrone(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestGetDrone(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/drone", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetDrones(ec.Request().Context(), repository.GetDronesInput{}).Return(repository.GetDronesOutput{
+			Drones: []repository.Drone{
+				repository.Drone{Id: id, Model: "Surveyor X1", MaxRange: 5000, MaxAltitude: 120, CruiseSpeed: 12, ClimbRate: 4, BatteryCapacity: 90},
+			},
+		}, nil)
+
+		err := server.GetDrone(ec)
+
+		resp := readJson[generated.DroneListResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.DroneListResponse{
+			Drones: []generated.DroneResponse{
+				generated.DroneResponse{Id: id, Model: "Surveyor X1", MaxRange: 5000, MaxAltitude: 120, CruiseSpeed: 12, ClimbRate: 4, BatteryCapacity: 90},
+			},
+		}, resp)
+	})
+
+	t.Run("Return 500 when get drones error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/drone", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetDrones(ec.Request().Context(), gomock.Any()).Return(repository.GetDronesOutput{}, errAny)
+
+		err := server.GetDrone(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestGetDroneId(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/drone/:id", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), repository.GetDroneByIdInput{
+			Id: id,
+		}).Return(repository.GetDroneByIdOutput{
+			Drone: repository.Drone{Id: id, Model: "Surveyor X1", MaxRange: 5000, MaxAltitude: 120, CruiseSpeed: 12, ClimbRate: 4, BatteryCapacity: 90},
+		}, nil)
+
+		err := server.GetDroneId(ec, id)
+
+		resp := readJson[generated.DroneResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.DroneResponse{Id: id, Model: "Surveyor X1", MaxRange: 5000, MaxAltitude: 120, CruiseSpeed: 12, ClimbRate: 4, BatteryCapacity: 90}, resp)
+	})
+
+	t.Run("Return 404 when drone missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/drone/:id", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), gomock.Any()).Return(repository.GetDroneByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetDroneId(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("drone").Error(), resp["message"])
+	})
+}
+
+func TestPutDroneId(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/drone/:id", strings.NewReader("{\"model\": \"Surveyor X1\", \"max_range\": 5000, \"max_altitude\": 120, \"cruise_speed\": 12, \"climb_rate\": 4, \"battery_capacity\": 90}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().UpdateDrone(ec.Request().Context(), repository.UpdateDroneInput{
+			Drone: repository.Drone{Id: id, Model: "Surveyor X1", MaxRange: 5000, MaxAltitude: 120, CruiseSpeed: 12, ClimbRate: 4, BatteryCapacity: 90},
+		}).Return(nil)
+
+		err := server.PutDroneId(ec, id)
+
+		resp := readJson[generated.DroneResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.DroneResponse{Id: id, Model: "Surveyor X1", MaxRange: 5000, MaxAltitude: 120, CruiseSpeed: 12, ClimbRate: 4, BatteryCapacity: 90}, resp)
+	})
+
+	t.Run("Return 400 when max range is not positive", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/drone/:id", strings.NewReader("{\"model\": \"Surveyor X1\", \"max_range\": -1, \"max_altitude\": 120, \"cruise_speed\": 12, \"climb_rate\": 4, \"battery_capacity\": 90}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		err := server.PutDroneId(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("max_range").Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when drone missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/drone/:id", strings.NewReader("{\"model\": \"Surveyor X1\", \"max_range\": 5000, \"max_altitude\": 120, \"cruise_speed\": 12, \"climb_rate\": 4, \"battery_capacity\": 90}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().UpdateDrone(ec.Request().Context(), gomock.Any()).Return(sql.ErrNoRows)
+
+		err := server.PutDroneId(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("drone").Error(), resp["message"])
+	})
+}
+
+func TestDeleteDroneId(t *testing.T) {
+	t.Run("Return 204", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodDelete, "/drone/:id", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), repository.GetDroneByIdInput{
+			Id: id,
+		}).Return(repository.GetDroneByIdOutput{Drone: repository.Drone{Id: id}}, nil)
+		mockRepo.EXPECT().DeleteDrone(ec.Request().Context(), repository.DeleteDroneInput{
+			Id: id,
+		}).Return(nil)
+
+		err := server.DeleteDroneId(ec, id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resRecorder.Code)
+	})
+
+	t.Run("Return 404 when drone missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodDelete, "/drone/:id", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), gomock.Any()).Return(repository.GetDroneByIdOutput{}, sql.ErrNoRows)
+
+		err := server.DeleteDroneId(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("drone").Error(), resp["message"])
+	})
+
+	t.Run("Return 409 when drone flies a mission that has not ended", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodDelete, "/drone/:id", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), gomock.Any()).Return(repository.GetDroneByIdOutput{Drone: repository.Drone{Id: id}}, nil)
+		mockRepo.EXPECT().DeleteDrone(ec.Request().Context(), repository.DeleteDroneInput{
+			Id: id,
+		}).Return(sql.ErrNoRows)
+
+		err := server.DeleteDroneId(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resRecorder.Code)
+		assert.Equal(t, ErrDroneInMission.Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get drone error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodDelete, "/drone/:id", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), gomock.Any()).Return(repository.GetDroneByIdOutput{}, errAny)
+
+		err := server.DeleteDroneId(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestPostMission(t *testing.T) {
+	t.Run("Return 201 with the latest snapshot", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission", strings.NewReader("{\"estate_id\": \"estate-1\", \"drone_id\": \"drone-1\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		mockRepo.EXPECT().GetDronePlanSnapshot(ec.Request().Context(), repository.GetDronePlanSnapshotInput{
+			EstateId: "estate-1",
+		}).Return(repository.GetDronePlanSnapshotOutput{DronePlanSnapshot: repository.DronePlanSnapshot{Id: "snapshot-3", Version: 3}}, nil)
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), repository.GetDroneByIdInput{
+			Id: "drone-1",
+		}).Return(repository.GetDroneByIdOutput{}, nil)
+
+		var stored repository.Mission
+		mockRepo.EXPECT().CreateMission(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ context.Context, input repository.CreateMissionInput) (repository.CreateMissionOutput, error) {
+			stored = input.Mission
+
+			return repository.CreateMissionOutput{CreatedAt: createdAt}, nil
+		})
+
+		err := server.PostMission(ec)
+
+		resp := readJson[generated.MissionResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+		assert.Equal(t, "snapshot-3", stored.SnapshotId)
+		assert.Equal(t, missionStatusScheduled, stored.Status)
+		assert.Equal(t, stored.Id, resp.Id)
+		assert.Equal(t, "estate-1", resp.EstateId)
+		assert.Equal(t, 3, resp.SnapshotVersion)
+		assert.Equal(t, "drone-1", *resp.DroneId)
+		assert.Equal(t, missionStatusScheduled, resp.Status)
+		assert.True(t, createdAt.Equal(resp.CreatedAt))
+		assert.Nil(t, resp.StartedAt)
+	})
+
+	t.Run("Return 201 with the given snapshot version and no drone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission", strings.NewReader("{\"estate_id\": \"estate-1\", \"snapshot_version\": 1}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetDronePlanSnapshot(ec.Request().Context(), repository.GetDronePlanSnapshotInput{
+			EstateId: "estate-1",
+			Version:  1,
+		}).Return(repository.GetDronePlanSnapshotOutput{DronePlanSnapshot: repository.DronePlanSnapshot{Id: "snapshot-1", Version: 1}}, nil)
+		mockRepo.EXPECT().CreateMission(ec.Request().Context(), gomock.Any()).Return(repository.CreateMissionOutput{}, nil)
+
+		err := server.PostMission(ec)
+
+		resp := readJson[generated.MissionResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+		assert.Equal(t, 1, resp.SnapshotVersion)
+		assert.Nil(t, resp.DroneId)
+	})
+
+	t.Run("Return 400 when estate id is empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission", strings.NewReader("{}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostMission(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrEmptyBuilder("estate_id").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when snapshot version is zero", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission", strings.NewReader("{\"estate_id\": \"estate-1\", \"snapshot_version\": 0}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostMission(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("snapshot_version").Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when snapshot missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission", strings.NewReader("{\"estate_id\": \"estate-1\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetDronePlanSnapshot(ec.Request().Context(), gomock.Any()).Return(repository.GetDronePlanSnapshotOutput{}, sql.ErrNoRows)
+
+		err := server.PostMission(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("snapshot").Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when drone missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission", strings.NewReader("{\"estate_id\": \"estate-1\", \"drone_id\": \"drone-1\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetDronePlanSnapshot(ec.Request().Context(), gomock.Any()).Return(repository.GetDronePlanSnapshotOutput{}, nil)
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), gomock.Any()).Return(repository.GetDroneByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PostMission(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("drone").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when create mission error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission", strings.NewReader("{\"estate_id\": \"estate-1\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetDronePlanSnapshot(ec.Request().Context(), gomock.Any()).Return(repository.GetDronePlanSnapshotOutput{}, nil)
+		mockRepo.EXPECT().CreateMission(ec.Request().Context(), gomock.Any()).Return(repository.CreateMissionOutput{}, errAny)
+
+		err := server.PostMission(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestGetMission(t *testing.T) {
+	t.Run("Return 200 with the missions of the estate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusScheduled,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+		estateId, status := mission.EstateId, missionStatusScheduled
+
+		mockRepo.EXPECT().GetMissions(ec.Request().Context(), repository.GetMissionsInput{
+			EstateId: estateId,
+			Status:   status,
+		}).Return(repository.GetMissionsOutput{Missions: []repository.Mission{mission}}, nil)
+
+		err := server.GetMission(ec, generated.GetMissionParams{EstateId: &estateId, Status: &status})
+
+		resp := readJson[generated.MissionListResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Len(t, resp.Missions, 1)
+		assert.Equal(t, id, resp.Missions[0].Id)
+	})
+
+	t.Run("Return 200 with no missions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetMissions(ec.Request().Context(), repository.GetMissionsInput{}).Return(repository.GetMissionsOutput{}, nil)
+
+		err := server.GetMission(ec, generated.GetMissionParams{})
+
+		resp := readJson[generated.MissionListResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, []generated.MissionResponse{}, resp.Missions)
+	})
+
+	t.Run("Return 400 when status is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		status := "flying"
+
+		err := server.GetMission(ec, generated.GetMissionParams{Status: &status})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidMissionStatus.Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get missions error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetMissions(ec.Request().Context(), gomock.Any()).Return(repository.GetMissionsOutput{}, errAny)
+
+		err := server.GetMission(ec, generated.GetMissionParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestGetMissionId(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusAborted,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+		mission.AbortReason = sql.NullString{String: "strong wind", Valid: true}
+		mission.StartedAt = sql.NullTime{Time: time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC), Valid: true}
+		mission.EndedAt = sql.NullTime{Time: time.Date(2024, 1, 2, 4, 30, 0, 0, time.UTC), Valid: true}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+
+		err := server.GetMissionId(ec, id)
+
+		resp := readJson[generated.MissionResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, missionStatusAborted, resp.Status)
+		assert.Equal(t, "strong wind", *resp.AbortReason)
+		assert.True(t, mission.StartedAt.Time.Equal(*resp.StartedAt))
+		assert.True(t, mission.EndedAt.Time.Equal(*resp.EndedAt))
+	})
+
+	t.Run("Return 404 when mission missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), gomock.Any()).Return(repository.GetMissionByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetMissionId(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("mission").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get mission error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), gomock.Any()).Return(repository.GetMissionByIdOutput{}, errAny)
+
+		err := server.GetMissionId(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestPutMissionIdDrone(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/drone", strings.NewReader("{\"drone_id\": \"drone-2\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusScheduled,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), repository.GetDroneByIdInput{
+			Id: "drone-2",
+		}).Return(repository.GetDroneByIdOutput{}, nil)
+		mockRepo.EXPECT().UpdateMissionDrone(ec.Request().Context(), repository.UpdateMissionDroneInput{
+			Id:      id,
+			DroneId: "drone-2",
+		}).Return(nil)
+
+		err := server.PutMissionIdDrone(ec, id)
+
+		resp := readJson[generated.MissionResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, "drone-2", *resp.DroneId)
+	})
+
+	t.Run("Return 400 when drone id is empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/drone", strings.NewReader("{}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PutMissionIdDrone(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrEmptyBuilder("drone_id").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when mission is in progress", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/drone", strings.NewReader("{\"drone_id\": \"drone-2\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusInProgress,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+
+		err := server.PutMissionIdDrone(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrMissionNotScheduled.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when mission started in the meantime", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/drone", strings.NewReader("{\"drone_id\": \"drone-2\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusScheduled,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), gomock.Any()).Return(repository.GetDroneByIdOutput{}, nil)
+		mockRepo.EXPECT().UpdateMissionDrone(ec.Request().Context(), gomock.Any()).Return(sql.ErrNoRows)
+
+		err := server.PutMissionIdDrone(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrMissionNotScheduled.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when drone missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/drone", strings.NewReader("{\"drone_id\": \"drone-2\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusScheduled,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+		mockRepo.EXPECT().GetDroneById(ec.Request().Context(), gomock.Any()).Return(repository.GetDroneByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PutMissionIdDrone(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("drone").Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when mission missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/drone", strings.NewReader("{\"drone_id\": \"drone-2\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), gomock.Any()).Return(repository.GetMissionByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PutMissionIdDrone(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("mission").Error(), resp["message"])
+	})
+}
+
+func TestPutMissionIdStatus(t *testing.T) {
+	t.Run("Return 200 when the mission starts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/status", strings.NewReader("{\"status\": \"in_progress\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+			Hub:        NewMissionHub(),
+		}
+
+		id := uuid.New().String()
+		events, unsubscribe := server.Hub.subscribe(id)
+		defer unsubscribe()
+		startedAt := sql.NullTime{Time: time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC), Valid: true}
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusScheduled,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+		mockRepo.EXPECT().UpdateMissionStatus(ec.Request().Context(), repository.UpdateMissionStatusInput{
+			Id:   id,
+			From: missionStatusScheduled,
+			To:   missionStatusInProgress,
+		}).Return(repository.UpdateMissionStatusOutput{StartedAt: startedAt}, nil)
+
+		err := server.PutMissionIdStatus(ec, id)
+
+		resp := readJson[generated.MissionResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, missionStatusInProgress, resp.Status)
+		assert.True(t, startedAt.Time.Equal(*resp.StartedAt))
+		assert.Nil(t, resp.EndedAt)
+		assert.Equal(t, missionEvent{name: missionEventStatus, data: resp}, <-events)
+	})
+
+	t.Run("Return 200 when the mission is aborted with a reason", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/status", strings.NewReader("{\"status\": \"aborted\", \"reason\": \"strong wind\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		endedAt := sql.NullTime{Time: time.Date(2024, 1, 2, 4, 30, 0, 0, time.UTC), Valid: true}
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusInProgress,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+		mockRepo.EXPECT().UpdateMissionStatus(ec.Request().Context(), repository.UpdateMissionStatusInput{
+			Id:          id,
+			From:        missionStatusInProgress,
+			To:          missionStatusAborted,
+			AbortReason: sql.NullString{String: "strong wind", Valid: true},
+		}).Return(repository.UpdateMissionStatusOutput{EndedAt: endedAt}, nil)
+
+		err := server.PutMissionIdStatus(ec, id)
+
+		resp := readJson[generated.MissionResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, missionStatusAborted, resp.Status)
+		assert.Equal(t, "strong wind", *resp.AbortReason)
+		assert.True(t, endedAt.Time.Equal(*resp.EndedAt))
+	})
+
+	t.Run("Return 400 when status is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/status", strings.NewReader("{\"status\": \"flying\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PutMissionIdStatus(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidMissionStatus.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when aborting without a reason", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/status", strings.NewReader("{\"status\": \"aborted\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PutMissionIdStatus(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrEmptyBuilder("reason").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when completing with a reason", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/status", strings.NewReader("{\"status\": \"completed\", \"reason\": \"done\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PutMissionIdStatus(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrParamConflictBuilder("reason", missionStatusCompleted).Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when a scheduled mission completes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/status", strings.NewReader("{\"status\": \"completed\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusScheduled,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+
+		err := server.PutMissionIdStatus(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrMissionTransitionBuilder(missionStatusScheduled, missionStatusCompleted).Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when a completed mission is aborted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/status", strings.NewReader("{\"status\": \"aborted\", \"reason\": \"strong wind\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusCompleted,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+
+		err := server.PutMissionIdStatus(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrMissionTransitionBuilder(missionStatusCompleted, missionStatusAborted).Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when the mission starts without a drone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/status", strings.NewReader("{\"status\": \"in_progress\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{},
+			Status:          missionStatusScheduled,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+
+		err := server.PutMissionIdStatus(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrMissionWithoutDrone.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when the mission moved in the meantime", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/status", strings.NewReader("{\"status\": \"in_progress\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusScheduled,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+		mockRepo.EXPECT().UpdateMissionStatus(ec.Request().Context(), gomock.Any()).Return(repository.UpdateMissionStatusOutput{}, sql.ErrNoRows)
+
+		err := server.PutMissionIdStatus(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrMissionTransitionBuilder(missionStatusScheduled, missionStatusInProgress).Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when mission missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/status", strings.NewReader("{\"status\": \"in_progress\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), gomock.Any()).Return(repository.GetMissionByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PutMissionIdStatus(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("mission").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when update status error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/mission/:id/status", strings.NewReader("{\"status\": \"in_progress\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+		errAny := errors.New("any error")
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        uuid.New().String(),
+			SnapshotId:      uuid.New().String(),
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "drone-1", Valid: true},
+			Status:          missionStatusScheduled,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: mission}, nil)
+		mockRepo.EXPECT().UpdateMissionStatus(ec.Request().Context(), gomock.Any()).Return(repository.UpdateMissionStatusOutput{}, errAny)
+
+		err := server.PutMissionIdStatus(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestPostMissionIdTelemetry(t *testing.T) {
+	t.Run("Return 200 with the stored count", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission/:id/telemetry", strings.NewReader("{\"points\": [{\"timestamp\": \"2024-01-02T03:04:05Z\", \"x\": 0, \"y\": 1, \"altitude\": 1, \"battery\": 100}, {\"timestamp\": \"2024-01-02T03:04:06Z\", \"x\": 1, \"y\": 1, \"altitude\": 11.5, \"battery\": 99.5}]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+			Hub:        NewMissionHub(),
+		}
+
+		id, estateId := uuid.New().String(), uuid.New().String()
+		events, unsubscribe := server.Hub.subscribe(id)
+		defer unsubscribe()
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: repository.Mission{Id: id, EstateId: estateId, SnapshotVersion: 2, Status: missionStatusInProgress}}, nil)
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: estateId,
+		}).Return(repository.GetEstateByIdOutput{Id: estateId, Length: 3, Width: 2, HomeX: sql.NullInt64{Int64: 0, Valid: true}, HomeY: sql.NullInt64{Int64: 1, Valid: true}}, nil)
+		mockRepo.EXPECT().StoreMissionTelemetry(ec.Request().Context(), repository.StoreMissionTelemetryInput{
+			MissionId: id,
+			Points: []repository.TelemetryPoint{
+				{RecordedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), X: 0, Y: 1, Altitude: 1, Battery: 100},
+				{RecordedAt: time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC), X: 1, Y: 1, Altitude: 11.5, Battery: 99.5},
+			},
+		}).Return(repository.StoreMissionTelemetryOutput{Stored: 1}, nil)
+
+		err := server.PostMissionIdTelemetry(ec, id)
+
+		resp := readJson[generated.TelemetryBatchResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.TelemetryBatchResponse{Received: 2, Stored: 1}, resp)
+		assert.Equal(t, missionEvent{name: missionEventTelemetry, data: generated.MissionTelemetryEvent{
+			Points: []generated.TelemetryPointResponse{
+				{Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), X: 0, Y: 1, Altitude: 1, Battery: 100},
+				{Timestamp: time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC), X: 1, Y: 1, Altitude: 11.5, Battery: 99.5},
+			},
+		}}, <-events)
+	})
+
+	t.Run("Return 400 when there are no points", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission/:id/telemetry", strings.NewReader("{\"points\": []}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostMissionIdTelemetry(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrEmptyBuilder("points").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when timestamp is missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission/:id/telemetry", strings.NewReader("{\"points\": [{\"x\": 1, \"y\": 1, \"altitude\": 11, \"battery\": 100}]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostMissionIdTelemetry(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrEmptyBuilder("timestamp").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when battery is over 100", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission/:id/telemetry", strings.NewReader("{\"points\": [{\"timestamp\": \"2024-01-02T03:04:05Z\", \"x\": 1, \"y\": 1, \"altitude\": 11, \"battery\": 101}]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostMissionIdTelemetry(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidBattery.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when mission has not started", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission/:id/telemetry", strings.NewReader("{\"points\": [{\"timestamp\": \"2024-01-02T03:04:05Z\", \"x\": 0, \"y\": 1, \"altitude\": 1, \"battery\": 100}, {\"timestamp\": \"2024-01-02T03:04:06Z\", \"x\": 1, \"y\": 1, \"altitude\": 11.5, \"battery\": 99.5}]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, estateId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: repository.Mission{Id: id, EstateId: estateId, SnapshotVersion: 2, Status: missionStatusScheduled}}, nil)
+
+		err := server.PostMissionIdTelemetry(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrMissionNotStarted.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when point is outside the estate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission/:id/telemetry", strings.NewReader("{\"points\": [{\"timestamp\": \"2024-01-02T03:04:05Z\", \"x\": 4, \"y\": 1, \"altitude\": 11, \"battery\": 100}]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, estateId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: repository.Mission{Id: id, EstateId: estateId, SnapshotVersion: 2, Status: missionStatusInProgress}}, nil)
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: estateId,
+		}).Return(repository.GetEstateByIdOutput{Id: estateId, Length: 3, Width: 2, HomeX: sql.NullInt64{Int64: 0, Valid: true}, HomeY: sql.NullInt64{Int64: 1, Valid: true}}, nil)
+
+		err := server.PostMissionIdTelemetry(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrCoordinateOutOfBound.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when mission missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission/:id/telemetry", strings.NewReader("{\"points\": [{\"timestamp\": \"2024-01-02T03:04:05Z\", \"x\": 0, \"y\": 1, \"altitude\": 1, \"battery\": 100}, {\"timestamp\": \"2024-01-02T03:04:06Z\", \"x\": 1, \"y\": 1, \"altitude\": 11.5, \"battery\": 99.5}]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), gomock.Any()).Return(repository.GetMissionByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PostMissionIdTelemetry(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("mission").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when store telemetry error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/mission/:id/telemetry", strings.NewReader("{\"points\": [{\"timestamp\": \"2024-01-02T03:04:05Z\", \"x\": 0, \"y\": 1, \"altitude\": 1, \"battery\": 100}, {\"timestamp\": \"2024-01-02T03:04:06Z\", \"x\": 1, \"y\": 1, \"altitude\": 11.5, \"battery\": 99.5}]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, estateId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: repository.Mission{Id: id, EstateId: estateId, SnapshotVersion: 2, Status: missionStatusCompleted}}, nil)
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: estateId,
+		}).Return(repository.GetEstateByIdOutput{Id: estateId, Length: 3, Width: 2, HomeX: sql.NullInt64{Int64: 0, Valid: true}, HomeY: sql.NullInt64{Int64: 1, Valid: true}}, nil)
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().StoreMissionTelemetry(ec.Request().Context(), gomock.Any()).Return(repository.StoreMissionTelemetryOutput{}, errAny)
+
+		err := server.PostMissionIdTelemetry(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestGetMissionIdDeviation(t *testing.T) {
+	t.Run("Return 200 with the deviation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id/deviation", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, estateId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: repository.Mission{Id: id, EstateId: estateId, SnapshotVersion: 2, Status: missionStatusCompleted}}, nil)
+		mockRepo.EXPECT().GetDronePlanSnapshot(ec.Request().Context(), repository.GetDronePlanSnapshotInput{
+			EstateId: estateId,
+			Version:  2,
+		}).Return(repository.GetDronePlanSnapshotOutput{DronePlanSnapshot: repository.DronePlanSnapshot{
+			Waypoints: []repository.PlanWaypoint{
+				{X: 1, Y: 1, Altitude: 11},
+				{X: 2, Y: 1, Altitude: 6},
+			},
+		}}, nil)
+		mockRepo.EXPECT().GetMissionTelemetry(ec.Request().Context(), repository.GetMissionTelemetryInput{
+			MissionId: id,
+		}).Return(repository.GetMissionTelemetryOutput{Points: []repository.TelemetryPoint{
+			{X: 1, Y: 1, Altitude: 13, Battery: 100},
+		}}, nil)
+
+		err := server.GetMissionIdDeviation(ec, id)
+
+		resp := readJson[generated.MissionDeviationResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.MissionDeviationResponse{
+			Points:               1,
+			MaxAltitudeDeviation: 2,
+			MaxDeviationPlot:     &generated.PlotResponse{X: 1, Y: 1},
+			SkippedPlots:         []generated.PlotResponse{{X: 2, Y: 1}},
+			PlannedDistance:      15,
+		}, resp)
+	})
+
+	t.Run("Return 404 when mission missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id/deviation", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), gomock.Any()).Return(repository.GetMissionByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetMissionIdDeviation(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("mission").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get telemetry error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id/deviation", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, estateId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: repository.Mission{Id: id, EstateId: estateId, SnapshotVersion: 2, Status: missionStatusCompleted}}, nil)
+		mockRepo.EXPECT().GetDronePlanSnapshot(ec.Request().Context(), repository.GetDronePlanSnapshotInput{
+			EstateId: estateId,
+			Version:  2,
+		}).Return(repository.GetDronePlanSnapshotOutput{DronePlanSnapshot: repository.DronePlanSnapshot{
+			Waypoints: []repository.PlanWaypoint{
+				{X: 1, Y: 1, Altitude: 11},
+				{X: 2, Y: 1, Altitude: 6},
+			},
+		}}, nil)
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetMissionTelemetry(ec.Request().Context(), gomock.Any()).Return(repository.GetMissionTelemetryOutput{}, errAny)
+
+		err := server.GetMissionIdDeviation(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestGetMissionIdStream(t *testing.T) {
+	t.Run("Return 200 with the status and the updates until the mission ends", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id/stream", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+			Hub:        NewMissionHub(),
+		}
+
+		id := uuid.New().String()
+
+		mission := repository.Mission{
+			Id:              id,
+			EstateId:        "estate-1",
+			SnapshotVersion: 2,
+			Status:          missionStatusInProgress,
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		// The updates come in while the stream is open
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).DoAndReturn(func(_ context.Context, _ repository.GetMissionByIdInput) (repository.GetMissionByIdOutput, error) {
+			server.Hub.publish(id, missionEvent{name: missionEventTelemetry, data: generated.MissionTelemetryEvent{
+				Points: []generated.TelemetryPointResponse{
+					{Timestamp: time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC), X: 1, Y: 1, Altitude: 11, Battery: 99},
+				},
+			}})
+			server.Hub.publish(id, missionEvent{name: missionEventStatus, data: generated.MissionResponse{
+				Id:              id,
+				EstateId:        "estate-1",
+				SnapshotVersion: 2,
+				Status:          missionStatusCompleted,
+				CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			}})
+
+			return repository.GetMissionByIdOutput{Mission: mission}, nil
+		})
+
+		err := server.GetMissionIdStream(ec, id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, "text/event-stream", resRecorder.Header().Get("Content-Type"))
+		assert.Equal(t, "event: status\n"+
+			"data: {\"created_at\":\"2024-01-02T03:04:05Z\",\"estate_id\":\"estate-1\",\"id\":\""+id+"\",\"snapshot_version\":2,\"status\":\"in_progress\"}\n\n"+
+			"event: telemetry\n"+
+			"data: {\"points\":[{\"altitude\":11,\"battery\":99,\"timestamp\":\"2024-01-02T03:04:06Z\",\"x\":1,\"y\":1}]}\n\n"+
+			"event: status\n"+
+			"data: {\"created_at\":\"2024-01-02T03:04:05Z\",\"estate_id\":\"estate-1\",\"id\":\""+id+"\",\"snapshot_version\":2,\"status\":\"completed\"}\n\n", resRecorder.Body.String())
+		assert.Empty(t, server.Hub.subscribers)
+	})
+
+	t.Run("Return 200 and close the stream once the viewer falls behind on a status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id/stream", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+			Hub:        NewMissionHub(),
+		}
+
+		id := uuid.New().String()
+
+		// The buffer fills up before the stream reads it, so the status that
+		// ends the mission cannot be queued
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).DoAndReturn(func(_ context.Context, _ repository.GetMissionByIdInput) (repository.GetMissionByIdOutput, error) {
+			for i := 0; i < hubBuffer; i++ {
+				server.Hub.publish(id, missionEvent{name: missionEventTelemetry, data: generated.MissionTelemetryEvent{}})
+			}
+			server.Hub.publish(id, missionEvent{name: missionEventStatus, data: generated.MissionResponse{Id: id, Status: missionStatusCompleted}})
+
+			return repository.GetMissionByIdOutput{Mission: repository.Mission{Id: id, Status: missionStatusInProgress}}, nil
+		})
+
+		err := server.GetMissionIdStream(ec, id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, hubBuffer, strings.Count(resRecorder.Body.String(), "event: telemetry\n"))
+		assert.NotContains(t, resRecorder.Body.String(), "\"status\":\"completed\"")
+		assert.Empty(t, server.Hub.subscribers)
+	})
+
+	t.Run("Return 200 with the status on a server built without a hub", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id/stream", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := NewServer(NewServerOptions{
+			Repository: mockRepo,
+		})
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: repository.Mission{Id: id, Status: missionStatusCompleted}}, nil)
+
+		var err error
+		assert.NotPanics(t, func() {
+			err = server.GetMissionIdStream(ec, id)
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Contains(t, resRecorder.Body.String(), "event: status\n")
+	})
+
+	t.Run("Return 200 with the status only when the mission has ended", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id/stream", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+			Hub:        NewMissionHub(),
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: repository.Mission{Id: id, Status: missionStatusAborted}}, nil)
+
+		err := server.GetMissionIdStream(ec, id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, 1, strings.Count(resRecorder.Body.String(), "event: status\n"))
+		assert.Contains(t, resRecorder.Body.String(), "\"status\":\"aborted\"")
+	})
+
+	t.Run("Return 200 when the viewer leaves", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		reqCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id/stream", nil).WithContext(reqCtx)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+			Hub:        NewMissionHub(),
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: id,
+		}).Return(repository.GetMissionByIdOutput{Mission: repository.Mission{Id: id, Status: missionStatusInProgress}}, nil)
+
+		err := server.GetMissionIdStream(ec, id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Contains(t, resRecorder.Body.String(), "\"status\":\"in_progress\"")
+		assert.Empty(t, server.Hub.subscribers)
+	})
+
+	t.Run("Return 404 when mission missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id/stream", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+			Hub:        NewMissionHub(),
+		}
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), gomock.Any()).Return(repository.GetMissionByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetMissionIdStream(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("mission").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get mission error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/mission/:id/stream", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+			Hub:        NewMissionHub(),
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), gomock.Any()).Return(repository.GetMissionByIdOutput{}, errAny)
+
+		err := server.GetMissionIdStream(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestPostEstateIdTreeTreeIdObservation(t *testing.T) {
+	t.Run("Return 201 when the observation is recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree/:treeId/observation", strings.NewReader("{\"health_score\": 4, \"suspected_disease\": \"leaf rust\", \"pests\": [\"aphid\"], \"fruit_count\": 0, \"notes\": \"yellow leaves\", \"mission_id\": \"mission-1\", \"observed_at\": \"2024-01-02T03:04:05Z\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10}}, nil)
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: "mission-1",
+		}).Return(repository.GetMissionByIdOutput{Mission: repository.Mission{Id: "mission-1", EstateId: id}}, nil)
+		mockRepo.EXPECT().CreateTreeObservation(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ context.Context, input repository.CreateTreeObservationInput) error {
+			assert.Equal(t, repository.CreateTreeObservationInput{
+				Id:               input.Id,
+				TreeId:           treeId,
+				MissionId:        sql.NullString{String: "mission-1", Valid: true},
+				HealthScore:      4,
+				SuspectedDisease: sql.NullString{String: "leaf rust", Valid: true},
+				Pests:            []string{"aphid"},
+				FruitCount:       sql.NullInt64{Int64: 0, Valid: true},
+				Notes:            sql.NullString{String: "yellow leaves", Valid: true},
+				ObservedAt:       sql.NullTime{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true},
+			}, input)
+
+			return nil
+		})
+
+		err := server.PostEstateIdTreeTreeIdObservation(ec, id, treeId)
+
+		resp := readJson[generated.UuidResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+		assert.NotEmpty(t, resp.Id)
+	})
+
+	t.Run("Return 201 when only the health is given", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree/:treeId/observation", strings.NewReader("{\"health_score\": 10}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10}}, nil)
+		mockRepo.EXPECT().CreateTreeObservation(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ context.Context, input repository.CreateTreeObservationInput) error {
+			assert.Equal(t, repository.CreateTreeObservationInput{
+				Id:          input.Id,
+				TreeId:      treeId,
+				HealthScore: 10,
+			}, input)
+
+			return nil
+		})
+
+		err := server.PostEstateIdTreeTreeIdObservation(ec, id, treeId)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+	})
+
+	t.Run("Return 400 when health score is missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree/:treeId/observation", strings.NewReader("{\"health_score\": 0}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdTreeTreeIdObservation(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrHealthOutOfRange.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when health score is over 10", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree/:treeId/observation", strings.NewReader("{\"health_score\": 11}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdTreeTreeIdObservation(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrHealthOutOfRange.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when fruit count is negative", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree/:treeId/observation", strings.NewReader("{\"health_score\": 5, \"fruit_count\": -1}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdTreeTreeIdObservation(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeBuilder("fruit_count").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when a pest is empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree/:treeId/observation", strings.NewReader("{\"health_score\": 5, \"pests\": [\"aphid\", \"\"]}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostEstateIdTreeTreeIdObservation(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrEmptyBuilder("pest").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when mission belongs to another estate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree/:treeId/observation", strings.NewReader("{\"health_score\": 5, \"mission_id\": \"mission-1\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10}}, nil)
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), repository.GetMissionByIdInput{
+			Id: "mission-1",
+		}).Return(repository.GetMissionByIdOutput{Mission: repository.Mission{Id: "mission-1", EstateId: uuid.New().String()}}, nil)
+
+		err := server.PostEstateIdTreeTreeIdObservation(ec, id, treeId)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrMissionOtherEstate.Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when tree missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree/:treeId/observation", strings.NewReader("{\"health_score\": 5}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), gomock.Any()).Return(repository.GetTreeByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PostEstateIdTreeTreeIdObservation(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("tree").Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when mission missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree/:treeId/observation", strings.NewReader("{\"health_score\": 5, \"mission_id\": \"mission-1\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10}}, nil)
+		mockRepo.EXPECT().GetMissionById(ec.Request().Context(), gomock.Any()).Return(repository.GetMissionByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PostEstateIdTreeTreeIdObservation(ec, id, treeId)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("mission").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when create observation error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree/:treeId/observation", strings.NewReader("{\"health_score\": 5}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10}}, nil)
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().CreateTreeObservation(ec.Request().Context(), gomock.Any()).Return(errAny)
+
+		err := server.PostEstateIdTreeTreeIdObservation(ec, id, treeId)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when tree is felled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/:id/tree/:treeId/observation", strings.NewReader("{\"health_score\": 4}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10, Status: treeStatusFelled}}, nil)
+
+		err := server.PostEstateIdTreeTreeIdObservation(ec, id, treeId)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrTreeFelled.Error(), resp["message"])
+	})
+}
+
+func TestGetEstateIdObservation(t *testing.T) {
+	t.Run("Return 200 with the observations of a tree", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/observation", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 2}, nil)
+		mockRepo.EXPECT().GetTreeObservations(ec.Request().Context(), repository.GetTreeObservationsInput{
+			EstateId: id,
+			TreeId:   "tree-1",
+		}).Return(repository.GetTreeObservationsOutput{Observations: []repository.TreeObservation{
+			repository.TreeObservation{
+				Id:               "observation-1",
+				TreeId:           "tree-1",
+				X:                2,
+				Y:                1,
+				MissionId:        sql.NullString{String: "mission-1", Valid: true},
+				HealthScore:      4,
+				SuspectedDisease: sql.NullString{String: "leaf rust", Valid: true},
+				Pests:            []string{"aphid"},
+				FruitCount:       sql.NullInt64{Int64: 12, Valid: true},
+				ObservedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				CreatedAt:        time.Date(2024, 1, 2, 3, 5, 0, 0, time.UTC),
+			},
+		}}, nil)
+
+		treeId := "tree-1"
+
+		err := server.GetEstateIdObservation(ec, id, generated.GetEstateIdObservationParams{TreeId: &treeId})
+
+		resp := readJson[generated.TreeObservationListResponse](t, resRecorder.Result())
+
+		missionId, disease, fruitCount := "mission-1", "leaf rust", 12
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.TreeObservationListResponse{Observations: []generated.TreeObservationResponse{
+			generated.TreeObservationResponse{
+				Id:               "observation-1",
+				TreeId:           "tree-1",
+				X:                2,
+				Y:                1,
+				MissionId:        &missionId,
+				HealthScore:      4,
+				SuspectedDisease: &disease,
+				Pests:            []string{"aphid"},
+				FruitCount:       &fruitCount,
+				ObservedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				CreatedAt:        time.Date(2024, 1, 2, 3, 5, 0, 0, time.UTC),
+			},
+		}}, resp)
+	})
+
+	t.Run("Return 200 with empty observations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/observation", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 2}, nil)
+		mockRepo.EXPECT().GetTreeObservations(ec.Request().Context(), repository.GetTreeObservationsInput{
+			EstateId:  id,
+			MissionId: "mission-1",
+		}).Return(repository.GetTreeObservationsOutput{}, nil)
+
+		missionId := "mission-1"
+
+		err := server.GetEstateIdObservation(ec, id, generated.GetEstateIdObservationParams{MissionId: &missionId})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, []any{}, resp["observations"])
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/observation", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetEstateIdObservation(ec, uuid.New().String(), generated.GetEstateIdObservationParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get observations error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/observation", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 2}, nil)
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetTreeObservations(ec.Request().Context(), gomock.Any()).Return(repository.GetTreeObservationsOutput{}, errAny)
+
+		err := server.GetEstateIdObservation(ec, id, generated.GetEstateIdObservationParams{})
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestGetEstateIdTreeHealth(t *testing.T) {
+	t.Run("Return 200 with the latest observation of every tree", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/tree-health", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 2}, nil)
+		mockRepo.EXPECT().GetLatestTreeObservations(ec.Request().Context(), repository.GetLatestTreeObservationsInput{
+			EstateId: id,
+		}).Return(repository.GetLatestTreeObservationsOutput{Observations: []repository.TreeObservation{
+			repository.TreeObservation{
+				Id:               "observation-1",
+				TreeId:           "tree-1",
+				X:                2,
+				Y:                1,
+				MissionId:        sql.NullString{String: "mission-1", Valid: true},
+				HealthScore:      4,
+				SuspectedDisease: sql.NullString{String: "leaf rust", Valid: true},
+				Pests:            []string{"aphid"},
+				FruitCount:       sql.NullInt64{Int64: 12, Valid: true},
+				ObservedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				CreatedAt:        time.Date(2024, 1, 2, 3, 5, 0, 0, time.UTC),
+			},
+		}}, nil)
+
+		err := server.GetEstateIdTreeHealth(ec, id)
+
+		resp := readJson[generated.TreeHealthListResponse](t, resRecorder.Result())
+
+		missionId, disease, fruitCount := "mission-1", "leaf rust", 12
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.TreeHealthListResponse{Trees: []generated.TreeObservationResponse{
+			generated.TreeObservationResponse{
+				Id:               "observation-1",
+				TreeId:           "tree-1",
+				X:                2,
+				Y:                1,
+				MissionId:        &missionId,
+				HealthScore:      4,
+				SuspectedDisease: &disease,
+				Pests:            []string{"aphid"},
+				FruitCount:       &fruitCount,
+				ObservedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				CreatedAt:        time.Date(2024, 1, 2, 3, 5, 0, 0, time.UTC),
+			},
+		}}, resp)
+	})
+
+	t.Run("Return 404 when estate missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/tree-health", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetEstateIdTreeHealth(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get latest observations error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/tree-health", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 2}, nil)
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetLatestTreeObservations(ec.Request().Context(), gomock.Any()).Return(repository.GetLatestTreeObservationsOutput{}, errAny)
+
+		err := server.GetEstateIdTreeHealth(ec, id)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestPostSpecies(t *testing.T) {
+	t.Run("Return 201", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/species", strings.NewReader("{\"name\": \"Oil Palm\", \"max_height\": 12}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().CreateSpecies(ec.Request().Context(), gomock.Any()).DoAndReturn(func(_ any, input repository.CreateSpeciesInput) error {
+			assert.Equal(t, "Oil Palm", input.Species.Name)
+			assert.Equal(t, 12, input.Species.MaxHeight)
+			assert.Equal(t, growthProfileModerate, input.Species.GrowthProfile)
+
+			return nil
+		})
+
+		err := server.PostSpecies(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resRecorder.Code)
+
+		_, err = uuid.Parse(resp["id"].(string))
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return 400 when name is empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/species", strings.NewReader("{\"max_height\": 12}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostSpecies(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrEmptyBuilder("name").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when max height is zero", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/species", strings.NewReader("{\"name\": \"Oil Palm\", \"max_height\": 0}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostSpecies(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrNegativeZeroBuilder("max_height").Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when growth profile is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/species", strings.NewReader("{\"name\": \"Oil Palm\", \"max_height\": 12, \"growth_profile\": \"rapid\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PostSpecies(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidGrowthProfile.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when species exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/species", strings.NewReader("{\"name\": \"Oil Palm\", \"max_height\": 12, \"growth_profile\": \"fast\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().CreateSpecies(ec.Request().Context(), gomock.Any()).Return(sql.ErrNoRows)
+
+		err := server.PostSpecies(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrSpeciesExist.Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when create species error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/species", strings.NewReader("{\"name\": \"Oil Palm\", \"max_height\": 12}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().CreateSpecies(ec.Request().Context(), gomock.Any()).Return(errAny)
+
+		err := server.PostSpecies(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestGetSpecies(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/species", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetAllSpecies(ec.Request().Context(), repository.GetAllSpeciesInput{}).Return(repository.GetAllSpeciesOutput{
+			Species: []repository.Species{
+				{Id: "durian", Name: "Durian", MaxHeight: 40, GrowthProfile: growthProfileSlow},
+				{Id: "palm", Name: "Oil Palm", MaxHeight: 12, GrowthProfile: growthProfileModerate},
+			},
+		}, nil)
+
+		err := server.GetSpecies(ec)
+
+		resp := readJson[generated.SpeciesListResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.SpeciesListResponse{
+			Species: []generated.SpeciesResponse{
+				{Id: "durian", Name: "Durian", MaxHeight: 40, GrowthProfile: growthProfileSlow},
+				{Id: "palm", Name: "Oil Palm", MaxHeight: 12, GrowthProfile: growthProfileModerate},
+			},
+		}, resp)
+	})
+
+	t.Run("Return 500 when get all species error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/species", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetAllSpecies(ec.Request().Context(), repository.GetAllSpeciesInput{}).Return(repository.GetAllSpeciesOutput{}, errAny)
+
+		err := server.GetSpecies(ec)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestGetSpeciesId(t *testing.T) {
+	t.Run("Return 200", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/species/:id", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id := uuid.New().String()
+
+		mockRepo.EXPECT().GetSpeciesById(ec.Request().Context(), repository.GetSpeciesByIdInput{
+			Id: id,
+		}).Return(repository.GetSpeciesByIdOutput{Species: repository.Species{Id: id, Name: "Oil Palm", MaxHeight: 12, GrowthProfile: growthProfileFast}}, nil)
+
+		err := server.GetSpeciesId(ec, id)
+
+		resp := readJson[generated.SpeciesResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.SpeciesResponse{Id: id, Name: "Oil Palm", MaxHeight: 12, GrowthProfile: growthProfileFast}, resp)
+	})
+
+	t.Run("Return 404 when species missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/species/:id", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetSpeciesById(ec.Request().Context(), gomock.Any()).Return(repository.GetSpeciesByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetSpeciesId(ec, uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("species").Error(), resp["message"])
+	})
+}
+
+func TestGetEstateIdTreeTreeId(t *testing.T) {
+	t.Run("Return 200 with the felled tree", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/tree/:treeId", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10, SpeciesId: sql.NullString{String: "palm", Valid: true}, Status: treeStatusFelled}}, nil)
+
+		err := server.GetEstateIdTreeTreeId(ec, id, treeId)
+
+		resp := readJson[generated.TreeResponse](t, resRecorder.Result())
+
+		speciesId := "palm"
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.TreeResponse{Id: treeId, X: 2, Y: 1, Height: 10, SpeciesId: &speciesId, Status: treeStatusFelled}, resp)
+	})
+
+	t.Run("Return 404 when tree missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/tree/:treeId", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), gomock.Any()).Return(repository.GetTreeByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetEstateIdTreeTreeId(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("tree").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get tree error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/tree/:treeId", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), gomock.Any()).Return(repository.GetTreeByIdOutput{}, errAny)
+
+		err := server.GetEstateIdTreeTreeId(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestPutEstateIdTreeTreeIdStatus(t *testing.T) {
+	t.Run("Return 200 when tree sickens", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/tree/:treeId/status", strings.NewReader("{\"status\": \"diseased\", \"reason\": \"root rot\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10, Status: treeStatusMature}}, nil)
+		mockRepo.EXPECT().UpdateTreeStatus(ec.Request().Context(), repository.UpdateTreeStatusInput{
+			Id:       treeId,
+			EstateId: id,
+			From:     treeStatusMature,
+			To:       treeStatusDiseased,
+			Reason:   sql.NullString{String: "root rot", Valid: true},
+		}).Return(nil)
+
+		err := server.PutEstateIdTreeTreeIdStatus(ec, id, treeId)
+
+		resp := readJson[generated.TreeResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.TreeResponse{Id: treeId, X: 2, Y: 1, Height: 10, Status: treeStatusDiseased}, resp)
+	})
+
+	t.Run("Return 200 and shorten the drone distance when tree is felled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/tree/:treeId/status", strings.NewReader("{\"status\": \"felled\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10, Status: treeStatusDiseased}}, nil)
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), repository.GetEstateByIdInput{
+			Id: id,
+		}).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), repository.GetEstateTreesInput{
+			EstateId: id,
+			MinX:     1,
+			MaxX:     3,
+			MinY:     1,
+			MaxY:     1,
+		}).Return(repository.GetEstateTreesOutput{
+			Trees: []repository.EstateTree{
+				{X: 1, Y: 1, Height: 4},
+				{Id: treeId, X: 2, Y: 1, Height: 10},
+			},
+		}, nil)
+		mockRepo.EXPECT().GetEstateElevations(ec.Request().Context(), repository.GetEstateElevationsInput{
+			EstateId: id,
+		}).Return(repository.GetEstateElevationsOutput{}, nil)
+		mockRepo.EXPECT().UpdateTreeStatus(ec.Request().Context(), repository.UpdateTreeStatusInput{
+			Id:       treeId,
+			EstateId: id,
+			From:     treeStatusDiseased,
+			To:       treeStatusFelled,
+
+			// The drone no longer climbs 10m over the tree and back down
+			DroneDistFactor: -12,
+		}).Return(nil)
+
+		err := server.PutEstateIdTreeTreeIdStatus(ec, id, treeId)
+
+		resp := readJson[generated.TreeResponse](t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, treeStatusFelled, resp.Status)
+	})
+
+	t.Run("Return 400 when status is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/tree/:treeId/status", strings.NewReader("{\"status\": \"dead\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		err := server.PutEstateIdTreeTreeIdStatus(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrInvalidTreeStatus.Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when tree is already felled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/tree/:treeId/status", strings.NewReader("{\"status\": \"mature\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10, Status: treeStatusFelled}}, nil)
+
+		err := server.PutEstateIdTreeTreeIdStatus(ec, id, treeId)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrTreeTransitionBuilder(treeStatusFelled, treeStatusMature).Error(), resp["message"])
+	})
+
+	t.Run("Return 400 when tree moved since it was read", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/tree/:treeId/status", strings.NewReader("{\"status\": \"mature\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10, Status: treeStatusPlanted}}, nil)
+		mockRepo.EXPECT().UpdateTreeStatus(ec.Request().Context(), gomock.Any()).Return(sql.ErrNoRows)
+
+		err := server.PutEstateIdTreeTreeIdStatus(ec, id, treeId)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resRecorder.Code)
+		assert.Equal(t, ErrTreeTransitionBuilder(treeStatusPlanted, treeStatusMature).Error(), resp["message"])
+	})
+
+	t.Run("Return 404 when tree missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/tree/:treeId/status", strings.NewReader("{\"status\": \"mature\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), gomock.Any()).Return(repository.GetTreeByIdOutput{}, sql.ErrNoRows)
+
+		err := server.PutEstateIdTreeTreeIdStatus(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("tree").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get estate trees error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/tree/:treeId/status", strings.NewReader("{\"status\": \"felled\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10, Status: treeStatusMature}}, nil)
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetEstateById(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateByIdOutput{Id: id, Length: 3, Width: 1}, nil)
+		mockRepo.EXPECT().GetEstateTrees(ec.Request().Context(), gomock.Any()).Return(repository.GetEstateTreesOutput{}, errAny)
+
+		err := server.PutEstateIdTreeTreeIdStatus(ec, id, treeId)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when update tree status error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPut, "/estate/:id/tree/:treeId/status", strings.NewReader("{\"status\": \"mature\"}"))
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+		ec.Request().Header.Set("Content-Type", "application/json")
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10, Status: treeStatusPlanted}}, nil)
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().UpdateTreeStatus(ec.Request().Context(), gomock.Any()).Return(errAny)
+
+		err := server.PutEstateIdTreeTreeIdStatus(ec, id, treeId)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
+}
+
+func TestGetEstateIdTreeTreeIdHistory(t *testing.T) {
+	t.Run("Return 200 with the history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/tree/:treeId/history", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10, Status: treeStatusFelled}}, nil)
+		mockRepo.EXPECT().GetTreeStatusHistory(ec.Request().Context(), repository.GetTreeStatusHistoryInput{
+			TreeId: treeId,
+		}).Return(repository.GetTreeStatusHistoryOutput{
+			History: []repository.TreeStatusChange{
+				{To: treeStatusPlanted, ChangedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+				{From: sql.NullString{String: treeStatusPlanted, Valid: true}, To: treeStatusFelled, Reason: sql.NullString{String: "storm", Valid: true}, ChangedAt: time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)},
+			},
+		}, nil)
+
+		err := server.GetEstateIdTreeTreeIdHistory(ec, id, treeId)
+
+		resp := readJson[generated.TreeStatusHistoryResponse](t, resRecorder.Result())
+
+		from, reason := treeStatusPlanted, "storm"
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resRecorder.Code)
+		assert.Equal(t, generated.TreeStatusHistoryResponse{
+			History: []generated.TreeStatusChangeResponse{
+				{To: treeStatusPlanted, ChangedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+				{From: &from, To: treeStatusFelled, Reason: &reason, ChangedAt: time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)},
+			},
+		}, resp)
+	})
+
+	t.Run("Return 404 when tree missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/tree/:treeId/history", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), gomock.Any()).Return(repository.GetTreeByIdOutput{}, sql.ErrNoRows)
+
+		err := server.GetEstateIdTreeTreeIdHistory(ec, uuid.New().String(), uuid.New().String())
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resRecorder.Code)
+		assert.Equal(t, ErrNotFoundBuilder("tree").Error(), resp["message"])
+	})
+
+	t.Run("Return 500 when get history error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/:id/tree/:treeId/history", nil)
+		resRecorder := httptest.NewRecorder()
+
+		ec := echo.New().NewContext(req, resRecorder)
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		server := Server{
+			Repository: mockRepo,
+		}
+
+		id, treeId := uuid.New().String(), uuid.New().String()
+
+		mockRepo.EXPECT().GetTreeById(ec.Request().Context(), repository.GetTreeByIdInput{
+			Id:       treeId,
+			EstateId: id,
+		}).Return(repository.GetTreeByIdOutput{EstateTree: repository.EstateTree{Id: treeId, X: 2, Y: 1, Height: 10, Status: treeStatusMature}}, nil)
+
+		errAny := errors.New("any error")
+
+		mockRepo.EXPECT().GetTreeStatusHistory(ec.Request().Context(), gomock.Any()).Return(repository.GetTreeStatusHistoryOutput{}, errAny)
+
+		err := server.GetEstateIdTreeTreeIdHistory(ec, id, treeId)
+
+		resp := readJsonResult(t, resRecorder.Result())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resRecorder.Code)
+		assert.Equal(t, errAny.Error(), resp["message"])
+	})
 }
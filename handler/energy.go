@@ -0,0 +1,137 @@
+package handler
+
+import "github.com/naufalfmm/plantation-drone-api/repository"
+
+// droneProfile is how fast the drone flies and how much power it draws in
+// every phase of the flight
+type droneProfile struct {
+	// cruiseSpeed is the horizontal speed, climbRate and descentRate the
+	// vertical ones, in meters per second
+	cruiseSpeed float64
+	climbRate   float64
+	descentRate float64
+
+	// cruisePower, climbPower and descentPower are the power drawn while
+	// flying horizontally, climbing and descending, in watts
+	cruisePower  float64
+	climbPower   float64
+	descentPower float64
+}
+
+// defaultDroneProfile is a typical plantation survey drone. Climbing draws
+// the most power, and descending less than cruising.
+var defaultDroneProfile = droneProfile{
+	cruiseSpeed: 10,
+	climbRate:   3,
+	descentRate: 5,
+
+	cruisePower:  400,
+	climbPower:   700,
+	descentPower: 250,
+}
+
+// withDrone flies the registered drone instead, drawing the power of the
+// given profile in every phase, and never beyond the drone range nor its
+// battery capacity
+func withDrone(profile droneProfile, limit flightLimit, drone repository.Drone) (droneProfile, flightLimit) {
+	profile.cruiseSpeed = drone.CruiseSpeed
+	profile.climbRate = drone.ClimbRate
+
+	if limit.maxDistance == 0 || drone.MaxRange < limit.maxDistance {
+		limit.maxDistance = drone.MaxRange
+	}
+
+	if limit.maxEnergy == 0 || drone.BatteryCapacity < limit.maxEnergy {
+		limit.maxEnergy = drone.BatteryCapacity
+	}
+
+	return profile, limit
+}
+
+// flightCost is what a flight takes from the drone
+type flightCost struct {
+	// distance is in meters, time in seconds and energy in watt-hours
+	distance int
+	time     float64
+	energy   float64
+}
+
+// cost estimates the flight from the meters flown horizontally, climbed and
+// descended
+func (p droneProfile) cost(horizontal, climb, descent int) flightCost {
+	cruiseTime := float64(horizontal) / p.cruiseSpeed
+	climbTime := float64(climb) / p.climbRate
+	descentTime := float64(descent) / p.descentRate
+
+	return flightCost{
+		distance: horizontal + climb + descent,
+		time:     cruiseTime + climbTime + descentTime,
+		energy:   (cruiseTime*p.cruisePower + climbTime*p.climbPower + descentTime*p.descentPower) / 3600,
+	}
+}
+
+// flightLimit is how far the drone can fly before it has to land. The zero
+// value of a limit means it is not limited by it.
+type flightLimit struct {
+	maxDistance int
+	maxEnergy   float64
+}
+
+func (l flightLimit) allows(cost flightCost) bool {
+	if l.maxDistance > 0 && cost.distance > l.maxDistance {
+		return false
+	}
+
+	if l.maxEnergy > 0 && cost.energy > l.maxEnergy {
+		return false
+	}
+
+	return true
+}
+
+// withReserve keeps the share of the limit in reserve, so the drone plans to
+// land with it left
+func (l flightLimit) withReserve(reserve float64) flightLimit {
+	l.maxDistance = int(float64(l.maxDistance) * (1 - reserve))
+	l.maxEnergy *= 1 - reserve
+
+	return l
+}
+
+// flightLegs splits the path into the meters flown horizontally, climbed and
+// descended, including the takeoff and the landing
+func flightLegs(path []flightWaypoint) (horizontal, climb, descent int) {
+	if len(path) == 0 {
+		return
+	}
+
+	climb = path[0].Altitude - path[0].Ground
+	for i := 1; i < len(path); i++ {
+		horizontal += plotDistance
+
+		if diff := path[i].Altitude - path[i-1].Altitude; diff > 0 {
+			climb += diff
+		} else {
+			descent -= diff
+		}
+	}
+
+	last := path[len(path)-1]
+	descent += last.Altitude - last.Ground
+
+	return
+}
+
+// splitDroneDistance splits the stored drone distance the same way as
+// flightLegs. Without obstacles the drone flies over every plot once, and it
+// climbs as much as it descends but for the ground it takes off from and
+// lands on.
+func splitDroneDistance(distance, length, width, takeoffGround, landingGround int) (horizontal, climb, descent int) {
+	horizontal = (length*width - 1) * plotDistance
+	vertical := distance - horizontal
+
+	climb = (vertical + landingGround - takeoffGround) / 2
+	descent = vertical - climb
+
+	return
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/naufalfmm/plantation-drone-api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDroneProfileCost(t *testing.T) {
+	cost := defaultDroneProfile.cost(20, 11, 11)
+
+	assert.Equal(t, 42, cost.distance)
+	// 20m at 10m/s, 11m up at 3m/s and 11m down at 5m/s
+	assert.InDelta(t, 2+11.0/3+2.2, cost.time, 1e-9)
+	assert.InDelta(t, (2*400+11.0/3*700+2.2*250)/3600, cost.energy, 1e-9)
+}
+
+func TestWithDrone(t *testing.T) {
+	drone := repository.Drone{MaxRange: 5000, CruiseSpeed: 12, ClimbRate: 4, BatteryCapacity: 90}
+
+	t.Run("Fly at the drone speeds within its range and battery", func(t *testing.T) {
+		profile, limit := withDrone(defaultDroneProfile, flightLimit{}, drone)
+
+		assert.Equal(t, 12.0, profile.cruiseSpeed)
+		assert.Equal(t, 4.0, profile.climbRate)
+		assert.Equal(t, defaultDroneProfile.descentRate, profile.descentRate)
+		assert.Equal(t, flightLimit{maxDistance: 5000, maxEnergy: 90}, limit)
+	})
+
+	t.Run("Keep the tighter limits", func(t *testing.T) {
+		_, limit := withDrone(defaultDroneProfile, flightLimit{maxDistance: 1000, maxEnergy: 120}, drone)
+
+		assert.Equal(t, flightLimit{maxDistance: 1000, maxEnergy: 90}, limit)
+	})
+}
+
+func TestFlightLimit(t *testing.T) {
+	cost := flightCost{distance: 42, time: 7.8, energy: 1.08}
+
+	assert.True(t, flightLimit{}.allows(cost))
+	assert.True(t, flightLimit{maxDistance: 42, maxEnergy: 1.1}.allows(cost))
+	assert.False(t, flightLimit{maxDistance: 41}.allows(cost))
+	assert.False(t, flightLimit{maxEnergy: 1}.allows(cost))
+
+	assert.Equal(t, flightLimit{maxDistance: 25, maxEnergy: 0.75}, flightLimit{maxDistance: 50, maxEnergy: 1.5}.withReserve(0.5))
+	assert.Equal(t, flightLimit{}, flightLimit{}.withReserve(0.2))
+}
+
+func TestFlightLegs(t *testing.T) {
+	t.Run("Return the legs of the flat estate", func(t *testing.T) {
+		horizontal, climb, descent := flightLegs(buildFlightPath(newAirspace([][]int{{0, 10, 0}}, nil, nil)))
+
+		assert.Equal(t, []int{20, 11, 11}, []int{horizontal, climb, descent})
+	})
+
+	t.Run("Return nothing for the empty path", func(t *testing.T) {
+		horizontal, climb, descent := flightLegs(nil)
+
+		assert.Equal(t, []int{0, 0, 0}, []int{horizontal, climb, descent})
+	})
+}
+
+func TestSplitDroneDistance(t *testing.T) {
+	path := buildFlightPath(newAirspace([][]int{{0, 10, 0}}, nil, []repository.PlotElevation{
+		{X: 3, Y: 1, Elevation: 4},
+	}))
+
+	horizontal, climb, descent := splitDroneDistance(flightDistance(path), 3, 1, 0, 4)
+	expHorizontal, expClimb, expDescent := flightLegs(path)
+
+	assert.Equal(t, []int{expHorizontal, expClimb, expDescent}, []int{horizontal, climb, descent})
+}
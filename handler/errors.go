@@ -12,8 +12,52 @@ var (
 	ErrNotFoundBuilder = func(f string) error {
 		return fmt.Errorf("%s not found", f)
 	}
+	ErrNegativeBuilder = func(f string) error {
+		return fmt.Errorf("%s is negative", f)
+	}
+	ErrEmptyBuilder = func(f string) error {
+		return fmt.Errorf("%s is empty", f)
+	}
+	ErrParamConflictBuilder = func(f, g string) error {
+		return fmt.Errorf("%s cannot be combined with %s", f, g)
+	}
+	ErrMissionTransitionBuilder = func(from, to string) error {
+		return fmt.Errorf("mission cannot move from %s to %s", from, to)
+	}
+	ErrSpeciesHeightBuilder = func(name string, maxHeight int) error {
+		return fmt.Errorf("height of %s must be 1 to %d", name, maxHeight)
+	}
+	ErrTreeTransitionBuilder = func(from, to string) error {
+		return fmt.Errorf("tree cannot move from %s to %s", from, to)
+	}
 
 	ErrHeightOutOfRange     = errors.New("height must be 1 to 30")
 	ErrCoordinateOutOfBound = errors.New("coordinate out of bound")
 	ErrTreeExist            = errors.New("plot already has tree")
+	ErrInvalidRegion        = errors.New("region minimum is greater than its maximum")
+	ErrInvalidGroupBy       = errors.New("group_by must be row, column or species")
+	ErrInvalidHeatmapFormat = errors.New("format must be json or png")
+	ErrObstacleExist        = errors.New("plot already has obstacle")
+	ErrObstacleEmpty        = errors.New("obstacle must have a height or be no-fly")
+	ErrElevationDimension   = errors.New("elevations must have width rows of length plots")
+	ErrInvalidFlightMode    = errors.New("mode must be canopy_following, constant_altitude or coverage")
+	ErrHomeOutOfBound       = errors.New("home must be inside the estate or at its boundary")
+	ErrInvalidReserve       = errors.New("reserve must be at least 0 and less than 1")
+	ErrCeilingExceeded      = errors.New("drone would fly above the altitude ceiling")
+	ErrInspectionEmpty      = errors.New("tree_ids, min_height or max_height must be given")
+	ErrInvalidHeightFilter  = errors.New("min_height is greater than max_height")
+	ErrDoseOverTank         = errors.New("a tree needs more chemical than the tank holds")
+	ErrSeedsOverCapacity    = errors.New("seeds_per_plot is greater than seed_capacity")
+	ErrInvalidMissionStatus = errors.New("status must be scheduled, in_progress, completed or aborted")
+	ErrMissionNotScheduled  = errors.New("drone can only be assigned to a scheduled mission")
+	ErrDroneInMission       = errors.New("drone is assigned to a mission that has not ended")
+	ErrMissionWithoutDrone  = errors.New("mission needs a drone to start")
+	ErrMissionNotStarted    = errors.New("mission has not started")
+	ErrInvalidBattery       = errors.New("battery must be 0 to 100")
+	ErrHealthOutOfRange     = errors.New("health_score must be 1 to 10")
+	ErrMissionOtherEstate   = errors.New("mission belongs to another estate")
+	ErrInvalidGrowthProfile = errors.New("growth_profile must be slow, moderate or fast")
+	ErrSpeciesExist         = errors.New("species already exists")
+	ErrInvalidTreeStatus    = errors.New("status must be planted, mature, diseased or felled")
+	ErrTreeFelled           = errors.New("tree has been felled")
 )
@@ -0,0 +1,493 @@
+package handler
+
+import "github.com/naufalfmm/plantation-drone-api/repository"
+
+const (
+	// plotDistance is the horizontal distance between two adjacent plots, in meters
+	plotDistance = 10
+	// droneClearance is how high the drone flies above the canopy or the ground, in meters
+	droneClearance = 1
+	// defaultCeiling is the highest the aviation rules let the drone fly above
+	// the ground, in meters, unless the estate has a lower limit
+	defaultCeiling = 120
+)
+
+const (
+	flightModeCanopyFollowing  = "canopy_following"
+	flightModeConstantAltitude = "constant_altitude"
+	flightModeCoverage         = "coverage"
+)
+
+// airspace is what the drone has to deal with above every plot of the estate,
+// indexed by [y-1][x-1] like the height grid
+type airspace struct {
+	length int
+	width  int
+
+	// ground is the terrain elevation of the plot
+	ground [][]int
+	// heights is the highest thing standing on the plot, be it a tree or an obstacle
+	heights [][]int
+	// noFly marks the plots the drone must not fly over
+	noFly [][]bool
+
+	// cruise is the altitude the drone keeps above every plot when it does
+	// not follow the canopy, 0 when it does
+	cruise int
+	// swath is how many rows the camera covers when the drone only flies
+	// along the middle row of every swath, 0 when it flies along every row
+	swath int
+	// stops are the only plots the drone visits, in order, nil when it
+	// visits the whole estate
+	stops [][2]int
+
+	// home is the plot the drone takes off from and lands back on, nil when
+	// it takes off at the first plot of the path and lands on the last one
+	home *[2]int
+}
+
+// newAirspace combines the tree height grid with the estate obstacles and
+// the terrain elevation. The plots without elevation are at ground level 0.
+func newAirspace(grid [][]int, obstacles []repository.EstateObstacle, elevations []repository.PlotElevation) airspace {
+	space := airspace{
+		width:   len(grid),
+		ground:  make([][]int, len(grid)),
+		heights: make([][]int, len(grid)),
+		noFly:   make([][]bool, len(grid)),
+	}
+	if space.width > 0 {
+		space.length = len(grid[0])
+	}
+
+	for y := range grid {
+		space.ground[y] = make([]int, space.length)
+		space.heights[y] = append([]int(nil), grid[y]...)
+		space.noFly[y] = make([]bool, space.length)
+	}
+
+	for _, elevation := range elevations {
+		space.ground[elevation.Y-1][elevation.X-1] = elevation.Elevation
+	}
+
+	for _, obstacle := range obstacles {
+		if obstacle.NoFly {
+			space.noFly[obstacle.Y-1][obstacle.X-1] = true
+		}
+
+		if obstacle.Height > space.heights[obstacle.Y-1][obstacle.X-1] {
+			space.heights[obstacle.Y-1][obstacle.X-1] = obstacle.Height
+		}
+	}
+
+	return space
+}
+
+// altitude is where the drone flies above the plot, measured from the same
+// datum as the terrain elevation
+func (s airspace) altitude(x, y int) int {
+	if s.cruise > 0 {
+		return s.cruise
+	}
+
+	if s.swath > 0 {
+		first, last := s.swathRows(y)
+
+		canopy := 0
+		for row := first; row <= last; row++ {
+			canopy = max(canopy, s.groundAt(x, row)+s.heightAt(x, row))
+		}
+
+		return canopy + droneClearance
+	}
+
+	return s.groundAt(x, y) + s.heightAt(x, y) + droneClearance
+}
+
+// groundAt is the terrain elevation of the plot. A plot at the boundary of
+// the estate lies at the elevation of the estate plot next to it.
+func (s airspace) groundAt(x, y int) int {
+	return s.ground[min(max(y, 1), s.width)-1][min(max(x, 1), s.length)-1]
+}
+
+// heightAt is the highest thing standing on the plot, 0 for a plot at the
+// boundary of the estate
+func (s airspace) heightAt(x, y int) int {
+	if !s.inside(x, y) {
+		return 0
+	}
+
+	return s.heights[y-1][x-1]
+}
+
+// atConstantAltitude makes the drone fly at the same altitude above every
+// plot, high enough to clear a tree of maxHeight on the highest ground and
+// everything actually standing on the estate
+func (s airspace) atConstantAltitude(maxHeight int) airspace {
+	cruise := 0
+	for y := range s.heights {
+		for x := range s.heights[y] {
+			cruise = max(cruise, s.ground[y][x]+maxHeight, s.ground[y][x]+s.heights[y][x])
+		}
+	}
+
+	s.cruise = cruise + droneClearance
+
+	return s
+}
+
+// withHome makes the drone take off from and land back on the home plot,
+// which is either inside the estate or right at its boundary
+func (s airspace) withHome(x, y int) airspace {
+	s.home = &[2]int{x, y}
+
+	return s
+}
+
+// withSwath makes the drone cover the estate with a camera as wide as swath
+// rows. It only flies along the middle row of every swath, above the tallest
+// canopy across the swath.
+func (s airspace) withSwath(swath int) airspace {
+	s.swath = swath
+
+	return s
+}
+
+// swathRows are the first and the last row of the swath the row belongs to.
+// The last swath is narrower when the rows do not split evenly.
+func (s airspace) swathRows(y int) (first, last int) {
+	first = (y-1)/s.swath*s.swath + 1
+	last = min(first+s.swath-1, s.width)
+
+	return first, last
+}
+
+// visiting makes the drone only visit the stops, in their order
+func (s airspace) visiting(stops [][2]int) airspace {
+	s.stops = stops
+
+	return s
+}
+
+// eachPlot visits the plots the drone flies over in the same serpentine
+// order as getPrevNextCoordinate, starting from plot (1, 1). With a swath it
+// only visits the middle row of every swath, turning back on every one, and
+// with stops it only visits them.
+func (s airspace) eachPlot(visit func(x, y int)) {
+	if s.stops != nil {
+		for _, stop := range s.stops {
+			visit(stop[0], stop[1])
+		}
+
+		return
+	}
+
+	if s.swath == 0 {
+		x, y := 1, 1
+		for y <= s.width {
+			visit(x, y)
+			_, _, x, y = getPrevNextCoordinate(x, y, s.length)
+		}
+
+		return
+	}
+
+	for first, forward := 1, true; first <= s.width; first, forward = first+s.swath, !forward {
+		_, last := s.swathRows(first)
+		y := (first + last) / 2
+
+		for i := 1; i <= s.length; i++ {
+			x := i
+			if !forward {
+				x = s.length - i + 1
+			}
+
+			visit(x, y)
+		}
+	}
+}
+
+func (s airspace) inside(x, y int) bool {
+	return x >= 1 && x <= s.length && y >= 1 && y <= s.width
+}
+
+func (s airspace) flyable(x, y int) bool {
+	if s.home != nil && x == s.home[0] && y == s.home[1] {
+		return true
+	}
+
+	return s.inside(x, y) && !s.noFly[y-1][x-1]
+}
+
+// route finds the shortest way from one plot to another without flying over
+// a no-fly plot. It returns the plots after the origin up to the destination,
+// or nil when the destination cannot be reached.
+func (s airspace) route(fromX, fromY, toX, toY int) [][2]int {
+	if abs(fromX-toX)+abs(fromY-toY) == 1 {
+		return [][2]int{{toX, toY}}
+	}
+
+	type plot = [2]int
+
+	prev := map[plot]plot{}
+	from, to := plot{fromX, fromY}, plot{toX, toY}
+	queue := []plot{from}
+	prev[from] = from
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if cur == to {
+			break
+		}
+
+		for _, next := range []plot{{cur[0] + 1, cur[1]}, {cur[0] - 1, cur[1]}, {cur[0], cur[1] + 1}, {cur[0], cur[1] - 1}} {
+			if _, seen := prev[next]; seen || !s.flyable(next[0], next[1]) {
+				continue
+			}
+
+			prev[next] = cur
+			queue = append(queue, next)
+		}
+	}
+
+	if _, ok := prev[to]; !ok {
+		return nil
+	}
+
+	var plots [][2]int
+	for cur := to; cur != from; cur = prev[cur] {
+		plots = append([][2]int{cur}, plots...)
+	}
+
+	return plots
+}
+
+// flightWaypoint is a plot visited by the drone along its flight path
+type flightWaypoint struct {
+	X        int
+	Y        int
+	Altitude int
+	// Ground is the terrain elevation below the waypoint, so Altitude - Ground
+	// is how far the drone climbs at takeoff or descends when landing there
+	Ground int
+
+	// Distance is the distance flown once the drone reaches the waypoint
+	// altitude above the plot, including the takeoff
+	Distance int
+
+	// Transit marks the plots the drone only passes over while detouring
+	// around a no-fly plot
+	Transit bool
+}
+
+// buildFlightPath walks the estate in the order of eachPlot. The drone takes
+// off vertically at the takeoffPlot and keeps droneClearance above every
+// plot. No-fly plots are skipped and detoured around, and the plots the drone
+// cannot reach without crossing a no-fly plot are left out.
+//
+// When the airspace has a home, the drone takes off there instead, ferries
+// to the first plot and ferries back home after the last one to land.
+func buildFlightPath(space airspace) []flightWaypoint {
+	path := make([]flightWaypoint, 0, space.length*space.width)
+
+	var start [2]int
+	if space.home != nil {
+		path = append(path, space.takeoff(space.home[0], space.home[1]))
+	} else {
+		start = space.takeoffPlot()
+	}
+
+	space.eachPlot(func(x, y int) {
+		if !space.flyable(x, y) {
+			return
+		}
+
+		if len(path) == 0 {
+			if [2]int{x, y} == start {
+				path = append(path, space.takeoff(x, y))
+			}
+		} else {
+			path = space.fly(path, x, y)
+		}
+	})
+
+	if space.home != nil && len(path) > 1 {
+		path = space.fly(path, space.home[0], space.home[1])
+	}
+
+	return path
+}
+
+// takeoffPlot is where the drone takes off without a home. The no-fly plots
+// may split the estate into parts the drone cannot fly between, so it takes
+// off at the first plot of the part with the most plots to visit, the first
+// part on a tie. It returns plot (0, 0) when no plot can be flown over.
+func (s airspace) takeoffPlot() (plot [2]int) {
+	part := map[[2]int]int{}
+	var firsts [][2]int
+	var visits []int
+
+	s.eachPlot(func(x, y int) {
+		if !s.flyable(x, y) {
+			return
+		}
+
+		id, seen := part[[2]int{x, y}]
+		if !seen {
+			id = len(firsts)
+			firsts = append(firsts, [2]int{x, y})
+			visits = append(visits, 0)
+
+			queue := [][2]int{{x, y}}
+			part[[2]int{x, y}] = id
+			for len(queue) > 0 {
+				cur := queue[0]
+				queue = queue[1:]
+
+				for _, next := range [][2]int{{cur[0] + 1, cur[1]}, {cur[0] - 1, cur[1]}, {cur[0], cur[1] + 1}, {cur[0], cur[1] - 1}} {
+					if _, seen := part[next]; seen || !s.flyable(next[0], next[1]) {
+						continue
+					}
+
+					part[next] = id
+					queue = append(queue, next)
+				}
+			}
+		}
+
+		visits[id]++
+	})
+
+	best := -1
+	for id := range firsts {
+		if best < 0 || visits[id] > visits[best] {
+			best = id
+		}
+	}
+
+	if best < 0 {
+		return plot
+	}
+
+	return firsts[best]
+}
+
+// takeoff is the first waypoint of a path, where the drone climbs vertically
+// from the ground to its altitude above the plot
+func (s airspace) takeoff(x, y int) flightWaypoint {
+	wp := flightWaypoint{
+		X:        x,
+		Y:        y,
+		Altitude: s.altitude(x, y),
+		Ground:   s.groundAt(x, y),
+	}
+	wp.Distance = wp.Altitude - wp.Ground
+
+	return wp
+}
+
+// fly extends the path from its last waypoint to the plot, passing over the
+// plots of the route in transit
+func (s airspace) fly(path []flightWaypoint, x, y int) []flightWaypoint {
+	last := path[len(path)-1]
+	plots := s.route(last.X, last.Y, x, y)
+
+	for i, plot := range plots {
+		prev := path[len(path)-1]
+		wp := flightWaypoint{
+			X:        plot[0],
+			Y:        plot[1],
+			Altitude: s.altitude(plot[0], plot[1]),
+			Ground:   s.groundAt(plot[0], plot[1]),
+			Transit:  i < len(plots)-1,
+		}
+		wp.Distance = prev.Distance + plotDistance + abs(wp.Altitude-prev.Altitude)
+
+		path = append(path, wp)
+	}
+
+	return path
+}
+
+// flightDistance is the total distance of the path, including the landing
+// at the last waypoint
+func flightDistance(path []flightWaypoint) int {
+	if len(path) == 0 {
+		return 0
+	}
+
+	last := path[len(path)-1]
+
+	return last.Distance + last.Altitude - last.Ground
+}
+
+// findRestPoint returns the index of the last waypoint the drone can reach
+// and still land there within the limit. The drone does not need to rest
+// when it can finish the whole path.
+func findRestPoint(path []flightWaypoint, limit flightLimit, profile droneProfile) (rest int, ok bool) {
+	if len(path) == 0 || limit.allows(profile.cost(flightLegs(path))) {
+		return
+	}
+
+	horizontal, climb, descent := 0, path[0].Altitude-path[0].Ground, 0
+	for i, wp := range path {
+		if i > 0 {
+			horizontal += plotDistance
+			if diff := wp.Altitude - path[i-1].Altitude; diff > 0 {
+				climb += diff
+			} else {
+				descent -= diff
+			}
+		}
+
+		if !limit.allows(profile.cost(horizontal, climb, descent+wp.Altitude-wp.Ground)) {
+			break
+		}
+
+		rest = i
+	}
+
+	return rest, true
+}
+
+// ceilingViolations returns the waypoints where the drone flies higher above
+// the ground than the ceiling, once for every plot. A ceiling of 0 does not
+// limit the drone.
+func ceilingViolations(path []flightWaypoint, ceiling int) []flightWaypoint {
+	if ceiling <= 0 {
+		return nil
+	}
+
+	var violations []flightWaypoint
+	seen := map[[2]int]bool{}
+	for _, wp := range path {
+		plot := [2]int{wp.X, wp.Y}
+		if wp.Altitude-wp.Ground <= ceiling || seen[plot] {
+			continue
+		}
+
+		seen[plot] = true
+		violations = append(violations, wp)
+	}
+
+	return violations
+}
+
+// droneDistFactor is how much the drone distance grows once a tree of the
+// given height stands on a plot, from the drone altitudes above the previous
+// and the next plot along the path
+func droneDistFactor(height, ground, prevAltitude, nextAltitude int) int {
+	before := ground + droneClearance
+	after := ground + height + droneClearance
+
+	return abs(prevAltitude-after) + abs(after-nextAltitude) - abs(prevAltitude-before) - abs(before-nextAltitude)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/naufalfmm/plantation-drone-api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildFlightPath(t *testing.T) {
+	t.Run("Follow the serpentine order of prev next coordinate", func(t *testing.T) {
+		grid := [][]int{
+			{0, 10, 0},
+			{5, 0, 0},
+		}
+
+		path := buildFlightPath(newAirspace(grid, nil, nil))
+
+		assert.Equal(t, []flightWaypoint{
+			{X: 1, Y: 1, Altitude: 1, Distance: 1},
+			{X: 2, Y: 1, Altitude: 11, Distance: 21},
+			{X: 3, Y: 1, Altitude: 1, Distance: 41},
+			{X: 3, Y: 2, Altitude: 1, Distance: 51},
+			{X: 2, Y: 2, Altitude: 1, Distance: 61},
+			{X: 1, Y: 2, Altitude: 6, Distance: 76},
+		}, path)
+		assert.Equal(t, 82, flightDistance(path))
+	})
+
+	t.Run("Match the base distance of an empty estate", func(t *testing.T) {
+		length, width := 6, 5
+		grid := buildHeightGrid(nil, length, width)
+
+		path := buildFlightPath(newAirspace(grid, nil, nil))
+
+		assert.Equal(t, (length-1)*10*width+(width-1)*10+2, flightDistance(path))
+	})
+}
+
+func TestFindRestPoint(t *testing.T) {
+	path := buildFlightPath(newAirspace([][]int{{0, 10, 0}}, nil, nil))
+
+	t.Run("Return no rest point when the path fits", func(t *testing.T) {
+		_, ok := findRestPoint(path, flightLimit{maxDistance: flightDistance(path)}, defaultDroneProfile)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("Return the last plot the drone can land on", func(t *testing.T) {
+		rest, ok := findRestPoint(path, flightLimit{maxDistance: 41}, defaultDroneProfile)
+
+		assert.True(t, ok)
+		assert.Equal(t, 1, rest)
+	})
+
+	t.Run("Return the first plot when the drone cannot leave it", func(t *testing.T) {
+		rest, ok := findRestPoint(path, flightLimit{maxDistance: 1}, defaultDroneProfile)
+
+		assert.True(t, ok)
+		assert.Equal(t, 0, rest)
+	})
+
+	t.Run("Return the last plot the drone can land on with the energy left", func(t *testing.T) {
+		rest, ok := findRestPoint(path, flightLimit{maxEnergy: 1}, defaultDroneProfile)
+
+		assert.True(t, ok)
+		assert.Equal(t, 1, rest)
+	})
+}
+
+func TestBuildFlightPathWithObstacles(t *testing.T) {
+	t.Run("Climb over the obstacle", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 10, 0}}, []repository.EstateObstacle{
+			{X: 2, Y: 1, Height: 15},
+			{X: 3, Y: 1, Height: 20},
+		}, nil)
+
+		path := buildFlightPath(space)
+
+		assert.Equal(t, []int{1, 16, 21}, []int{path[0].Altitude, path[1].Altitude, path[2].Altitude})
+		assert.Equal(t, 62, flightDistance(path))
+	})
+
+	t.Run("Detour around the no-fly plot", func(t *testing.T) {
+		space := newAirspace(buildHeightGrid(nil, 3, 3), []repository.EstateObstacle{
+			{X: 2, Y: 1, NoFly: true},
+		}, nil)
+
+		path := buildFlightPath(space)
+
+		assert.Equal(t, []flightWaypoint{
+			{X: 1, Y: 1, Altitude: 1, Distance: 1},
+			{X: 1, Y: 2, Altitude: 1, Distance: 11, Transit: true},
+			{X: 2, Y: 2, Altitude: 1, Distance: 21, Transit: true},
+			{X: 3, Y: 2, Altitude: 1, Distance: 31, Transit: true},
+			{X: 3, Y: 1, Altitude: 1, Distance: 41},
+		}, path[:5])
+		assert.Len(t, path, 11)
+		assert.Equal(t, 102, flightDistance(path))
+	})
+
+	t.Run("Leave out the plot enclosed by no-fly plots", func(t *testing.T) {
+		space := newAirspace(buildHeightGrid(nil, 3, 3), []repository.EstateObstacle{
+			{X: 3, Y: 2, NoFly: true},
+			{X: 2, Y: 3, NoFly: true},
+		}, nil)
+
+		path := buildFlightPath(space)
+
+		last := path[len(path)-1]
+		assert.Len(t, path, 7)
+		assert.Equal(t, flightWaypoint{X: 2, Y: 1, Altitude: 1, Distance: 31, Transit: true}, path[3])
+		assert.Equal(t, 1, last.X)
+		assert.Equal(t, 3, last.Y)
+	})
+	t.Run("Take off in the largest part the no-fly plots leave", func(t *testing.T) {
+		space := newAirspace(buildHeightGrid(nil, 3, 3), []repository.EstateObstacle{
+			{X: 2, Y: 1, NoFly: true},
+			{X: 1, Y: 2, NoFly: true},
+		}, nil)
+
+		path := buildFlightPath(space)
+
+		plots := make([][2]int, 0, len(path))
+		for _, wp := range path {
+			plots = append(plots, [2]int{wp.X, wp.Y})
+		}
+
+		// (1, 1) is boxed in, so taking off there would leave out the rest
+		// of the estate
+		assert.Equal(t, [][2]int{{3, 1}, {3, 2}, {2, 2}, {2, 3}, {1, 3}, {2, 3}, {3, 3}}, plots)
+		assert.True(t, path[3].Transit)
+	})
+}
+
+func TestTakeoffPlot(t *testing.T) {
+	t.Run("Take off at the first plot of an open estate", func(t *testing.T) {
+		space := newAirspace(buildHeightGrid(nil, 3, 2), nil, nil)
+
+		assert.Equal(t, [2]int{1, 1}, space.takeoffPlot())
+	})
+
+	t.Run("Take off at the first plot of the first part on a tie", func(t *testing.T) {
+		space := newAirspace(buildHeightGrid(nil, 3, 1), []repository.EstateObstacle{
+			{X: 2, Y: 1, NoFly: true},
+		}, nil)
+
+		assert.Equal(t, [2]int{1, 1}, space.takeoffPlot())
+	})
+
+	t.Run("Return no plot when every plot is no-fly", func(t *testing.T) {
+		space := newAirspace(buildHeightGrid(nil, 1, 1), []repository.EstateObstacle{
+			{X: 1, Y: 1, NoFly: true},
+		}, nil)
+
+		assert.Equal(t, [2]int{}, space.takeoffPlot())
+	})
+}
+
+func TestBuildFlightPathWithElevations(t *testing.T) {
+	t.Run("Follow the terrain", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 10, 0}}, nil, []repository.PlotElevation{
+			{X: 2, Y: 1, Elevation: 5},
+			{X: 3, Y: 1, Elevation: 8},
+		})
+
+		path := buildFlightPath(space)
+
+		assert.Equal(t, []int{1, 16, 9}, []int{path[0].Altitude, path[1].Altitude, path[2].Altitude})
+		// 1 takeoff + (10 + 15) + (10 + 7) + 1 landing
+		assert.Equal(t, 44, flightDistance(path))
+	})
+
+	t.Run("Land on the rest point above the ground", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 0, 0}}, nil, []repository.PlotElevation{
+			{X: 2, Y: 1, Elevation: 20},
+			{X: 3, Y: 1, Elevation: 20},
+		})
+
+		path := buildFlightPath(space)
+		rest, ok := findRestPoint(path, flightLimit{maxDistance: 40}, defaultDroneProfile)
+
+		assert.True(t, ok)
+		assert.Equal(t, flightWaypoint{X: 2, Y: 1, Altitude: 21, Ground: 20, Distance: 31}, path[rest])
+	})
+}
+
+func TestBuildFlightPathWithHome(t *testing.T) {
+	t.Run("Ferry from and back to the home at the boundary", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 5, 0}, {0, 0, 0}}, nil, nil).withHome(0, 1)
+
+		path := buildFlightPath(space)
+
+		assert.Equal(t, flightWaypoint{X: 0, Y: 1, Altitude: 1, Ground: 0, Distance: 1}, path[0])
+		assert.Equal(t, flightWaypoint{X: 1, Y: 1, Altitude: 1, Ground: 0, Distance: 81, Transit: true}, path[len(path)-2])
+		assert.Equal(t, flightWaypoint{X: 0, Y: 1, Altitude: 1, Ground: 0, Distance: 91}, path[len(path)-1])
+		// 62 over the estate + 10 ferrying out + 20 ferrying back
+		assert.Equal(t, 92, flightDistance(path))
+	})
+
+	t.Run("Take off from the ground next to the estate", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 0}}, nil, []repository.PlotElevation{
+			{X: 2, Y: 1, Elevation: 4},
+		}).withHome(3, 1)
+
+		path := buildFlightPath(space)
+
+		assert.Equal(t, flightWaypoint{X: 3, Y: 1, Altitude: 5, Ground: 4, Distance: 1}, path[0])
+		assert.Equal(t, flightWaypoint{X: 3, Y: 1, Altitude: 5, Ground: 4, Distance: 49}, path[len(path)-1])
+	})
+
+	t.Run("Stay home when no plot can be reached", func(t *testing.T) {
+		space := newAirspace([][]int{{0}}, []repository.EstateObstacle{{X: 1, Y: 1, NoFly: true}}, nil).withHome(1, 0)
+
+		path := buildFlightPath(space)
+
+		assert.Equal(t, []flightWaypoint{{X: 1, Y: 0, Altitude: 1, Ground: 0, Distance: 1}}, path)
+		assert.Equal(t, 2, flightDistance(path))
+	})
+}
+
+func TestBuildFlightPathWithSwath(t *testing.T) {
+	t.Run("Fly along the middle row of every swath", func(t *testing.T) {
+		space := newAirspace([][]int{
+			{10, 0},
+			{0, 0},
+			{0, 5},
+			{0, 0},
+			{7, 0},
+		}, nil, nil).withSwath(3)
+
+		path := buildFlightPath(space)
+
+		assert.Equal(t, []flightWaypoint{
+			{X: 1, Y: 2, Altitude: 11, Distance: 11},
+			{X: 2, Y: 2, Altitude: 6, Distance: 26},
+			{X: 2, Y: 3, Altitude: 6, Distance: 36, Transit: true},
+			{X: 2, Y: 4, Altitude: 1, Distance: 51},
+			{X: 1, Y: 4, Altitude: 8, Distance: 68},
+		}, path)
+		assert.Equal(t, 76, flightDistance(path))
+	})
+
+	t.Run("Fly along every row with a swath of one row", func(t *testing.T) {
+		grid := [][]int{{0, 10, 0}, {5, 0, 0}}
+
+		assert.Equal(t, buildFlightPath(newAirspace(grid, nil, nil)), buildFlightPath(newAirspace(grid, nil, nil).withSwath(1)))
+	})
+}
+
+func TestSwathRows(t *testing.T) {
+	space := newAirspace(make([][]int, 5), nil, nil).withSwath(3)
+
+	first, last := space.swathRows(2)
+	assert.Equal(t, []int{1, 3}, []int{first, last})
+
+	first, last = space.swathRows(5)
+	assert.Equal(t, []int{4, 5}, []int{first, last})
+}
+
+func TestCeilingViolations(t *testing.T) {
+	path := []flightWaypoint{
+		{X: 1, Y: 1, Altitude: 11, Ground: 0},
+		{X: 2, Y: 1, Altitude: 31, Ground: 5},
+		{X: 3, Y: 1, Altitude: 31, Ground: 0, Transit: true},
+		{X: 2, Y: 1, Altitude: 31, Ground: 5},
+	}
+
+	assert.Equal(t, []flightWaypoint{path[1], path[2]}, ceilingViolations(path, 25))
+	assert.Nil(t, ceilingViolations(path, 31))
+	assert.Nil(t, ceilingViolations(path, 0))
+}
+
+func TestDroneDistFactor(t *testing.T) {
+	t.Run("Same as the flat estate without elevation", func(t *testing.T) {
+		assert.Equal(t, -4, droneDistFactor(10, 0, 5+droneClearance, 7+droneClearance))
+	})
+
+	t.Run("Climb from the lower plot", func(t *testing.T) {
+		// Above the 8m plot the drone flies at 9m, between the 6m and 12m neighbours
+		assert.Equal(t, 14, droneDistFactor(10, 8, 5+droneClearance, 11+droneClearance))
+	})
+}
+
+func TestBuildFlightPathAtConstantAltitude(t *testing.T) {
+	t.Run("Fly above the tallest tree on the highest ground", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 10, 0}}, nil, []repository.PlotElevation{
+			{X: 3, Y: 1, Elevation: 4},
+		})
+
+		path := buildFlightPath(space.atConstantAltitude(10))
+
+		assert.Equal(t, []int{15, 15, 15}, []int{path[0].Altitude, path[1].Altitude, path[2].Altitude})
+		// 15 takeoff + 20 + 11 landing on the higher ground
+		assert.Equal(t, 46, flightDistance(path))
+	})
+
+	t.Run("Clear the obstacle taller than the trees", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 10, 0}}, []repository.EstateObstacle{
+			{X: 3, Y: 1, Height: 20},
+		}, nil)
+
+		path := buildFlightPath(space.atConstantAltitude(10))
+
+		assert.Equal(t, 21, path[0].Altitude)
+	})
+}
@@ -1,6 +1,30 @@
 package handler
 
-import "sort"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/naufalfmm/plantation-drone-api/generated"
+	"github.com/naufalfmm/plantation-drone-api/repository"
+)
+
+const (
+	statGroupByRow     = "row"
+	statGroupByColumn  = "column"
+	statGroupBySpecies = "species"
+
+	heatmapFormatJson = "json"
+	heatmapFormatPng  = "png"
+)
+
+// plotRegion is an inclusive rectangle of plots inside an estate
+type plotRegion struct {
+	minX, maxX int
+	minY, maxY int
+}
 
 func getPrevNextCoordinate(x, y, length int) (prevX, prevY, nextX, nextY int) {
 	if y%2 != 0 {
@@ -47,11 +71,649 @@ func findMedian(data []int) float64 {
 		return data[i] < data[j]
 	})
 
-	if len(data)%2 > 0 {
-		return float64(data[int(len(data)/2)])
+	if len(data) == 0 {
+		return 0
 	}
 
 	mid := int(len(data) / 2)
+	if len(data)%2 > 0 {
+		return float64(data[mid])
+	}
+
+	return float64(data[mid-1]+data[mid]) / 2
+}
+
+func isRegionalStats(params generated.GetEstateIdStatsParams) bool {
+	return params.Row != nil || params.Column != nil ||
+		params.MinX != nil || params.MaxX != nil ||
+		params.MinY != nil || params.MaxY != nil ||
+		params.GroupBy != nil ||
+		(params.Coverage != nil && *params.Coverage)
+}
+
+func buildStatRegion(params generated.GetEstateIdStatsParams, length, width int) (region plotRegion, err error) {
+	region = plotRegion{minX: 1, maxX: length, minY: 1, maxY: width}
+
+	if params.Row != nil {
+		if params.MinY != nil || params.MaxY != nil {
+			return region, ErrParamConflictBuilder("row", "min_y and max_y")
+		}
+
+		region.minY, region.maxY = *params.Row, *params.Row
+	}
+
+	if params.Column != nil {
+		if params.MinX != nil || params.MaxX != nil {
+			return region, ErrParamConflictBuilder("column", "min_x and max_x")
+		}
+
+		region.minX, region.maxX = *params.Column, *params.Column
+	}
+
+	if params.MinX != nil {
+		region.minX = *params.MinX
+	}
+	if params.MaxX != nil {
+		region.maxX = *params.MaxX
+	}
+	if params.MinY != nil {
+		region.minY = *params.MinY
+	}
+	if params.MaxY != nil {
+		region.maxY = *params.MaxY
+	}
+
+	if region.minX > region.maxX || region.minY > region.maxY {
+		return region, ErrInvalidRegion
+	}
+
+	if region.minX < 1 || region.maxX > length || region.minY < 1 || region.maxY > width {
+		return region, ErrCoordinateOutOfBound
+	}
+
+	return
+}
+
+func buildStat(heights []int) (count, maxHeight, minHeight int, median float64) {
+	for _, height := range heights {
+		if count == 0 || height > maxHeight {
+			maxHeight = height
+		}
+
+		if count == 0 || height < minHeight {
+			minHeight = height
+		}
+
+		count++
+	}
+
+	median = findMedian(heights)
+
+	return
+}
+
+// buildStatGroups breaks the trees down per row or per column of the region.
+// Every row or column of the region gets a group, even when it has no tree.
+func buildStatGroups(trees []repository.EstateTree, groupBy string, region plotRegion) []generated.EstateStatGroupResponse {
+	from, to := region.minY, region.maxY
+	if groupBy == statGroupByColumn {
+		from, to = region.minX, region.maxX
+	}
+
+	heights := make([][]int, to-from+1)
+	for _, tree := range trees {
+		idx := tree.Y
+		if groupBy == statGroupByColumn {
+			idx = tree.X
+		}
+
+		heights[idx-from] = append(heights[idx-from], tree.Height)
+	}
+
+	groups := make([]generated.EstateStatGroupResponse, len(heights))
+	for i := range heights {
+		groups[i].Index = from + i
+		groups[i].Count, groups[i].Max, groups[i].Min, groups[i].Median = buildStat(heights[i])
+	}
+
+	return groups
+}
+
+// buildSpeciesStatGroups breaks the trees down per species, in the order of
+// the catalogue. Only the species with a tree get a group, and the trees
+// without a species come last in a group without a species.
+func buildSpeciesStatGroups(trees []repository.EstateTree, catalogue []repository.Species) []generated.EstateSpeciesStatGroupResponse {
+	heights := map[string][]int{}
+	var unspecified []int
+	for _, tree := range trees {
+		if !tree.SpeciesId.Valid {
+			unspecified = append(unspecified, tree.Height)
+			continue
+		}
+
+		heights[tree.SpeciesId.String] = append(heights[tree.SpeciesId.String], tree.Height)
+	}
+
+	groups := []generated.EstateSpeciesStatGroupResponse{}
+	for _, species := range catalogue {
+		if len(heights[species.Id]) == 0 {
+			continue
+		}
+
+		id, name := species.Id, species.Name
+		group := generated.EstateSpeciesStatGroupResponse{
+			SpeciesId: &id,
+			Name:      &name,
+		}
+		group.Count, group.Max, group.Min, group.Median = buildStat(heights[species.Id])
+		groups = append(groups, group)
+	}
+
+	if len(unspecified) > 0 {
+		var group generated.EstateSpeciesStatGroupResponse
+		group.Count, group.Max, group.Min, group.Median = buildStat(unspecified)
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// buildHeightGrid lays the trees out as a width x length grid indexed by
+// [y-1][x-1], leaving 0 on the empty plots
+func buildHeightGrid(trees []repository.EstateTree, length, width int) [][]int {
+	grid := make([][]int, width)
+	for y := range grid {
+		grid[y] = make([]int, length)
+	}
+
+	for _, tree := range trees {
+		grid[tree.Y-1][tree.X-1] = tree.Height
+	}
+
+	return grid
+}
+
+// buildElevations validates the elevation grid against the estate size and
+// keeps only the plots above ground level 0
+func buildElevations(grid [][]int, length, width int) ([]repository.PlotElevation, error) {
+	if len(grid) != width {
+		return nil, ErrElevationDimension
+	}
+
+	var elevations []repository.PlotElevation
+	for y, row := range grid {
+		if len(row) != length {
+			return nil, ErrElevationDimension
+		}
+
+		for x, elevation := range row {
+			if elevation != 0 {
+				elevations = append(elevations, repository.PlotElevation{
+					X:         x + 1,
+					Y:         y + 1,
+					Elevation: elevation,
+				})
+			}
+		}
+	}
+
+	return elevations, nil
+}
+
+// buildElevationGrid lays the elevations out like buildHeightGrid, leaving 0
+// on the plots at ground level
+func buildElevationGrid(elevations []repository.PlotElevation, length, width int) [][]int {
+	grid := buildHeightGrid(nil, length, width)
+	for _, elevation := range elevations {
+		grid[elevation.Y-1][elevation.X-1] = elevation.Elevation
+	}
+
+	return grid
+}
+
+// validateHome accepts a home plot inside the estate or right next to one
+// of its edges, where x is 0 or length + 1, or y is 0 or width + 1. The
+// corners outside the estate do not touch any plot, so the drone could not
+// ferry from them.
+func validateHome(x, y, length, width int) error {
+	insideX := x >= 1 && x <= length
+	insideY := y >= 1 && y <= width
+
+	if x < 0 || x > length+1 || y < 0 || y > width+1 || (!insideX && !insideY) {
+		return ErrHomeOutOfBound
+	}
+
+	return nil
+}
+
+func findMaxHeight(trees []repository.EstateTree) (maxHeight int) {
+	for _, tree := range trees {
+		if tree.Height > maxHeight {
+			maxHeight = tree.Height
+		}
+	}
+
+	return
+}
+
+func (r plotRegion) contains(x, y int) bool {
+	return x >= r.minX && x <= r.maxX && y >= r.minY && y <= r.maxY
+}
+
+// buildCoverage measures how much of the region is planted. The longest empty
+// run only counts the empty plots the drone flies over one after another
+// without leaving the region.
+func buildCoverage(grid [][]int, region plotRegion, path []flightWaypoint) generated.EstateCoverageResponse {
+	rowLength := region.maxX - region.minX + 1
+
+	coverage := generated.EstateCoverageResponse{
+		TotalPlots: rowLength * (region.maxY - region.minY + 1),
+		Rows:       make([]generated.EstateRowDensityResponse, 0, region.maxY-region.minY+1),
+	}
+
+	for y := region.minY; y <= region.maxY; y++ {
+		row := generated.EstateRowDensityResponse{
+			Row: y,
+		}
+
+		for x := region.minX; x <= region.maxX; x++ {
+			if grid[y-1][x-1] > 0 {
+				row.PlantedPlots++
+			}
+		}
+
+		row.Density = float64(row.PlantedPlots) / float64(rowLength)
+		coverage.PlantedPlots += row.PlantedPlots
+		coverage.Rows = append(coverage.Rows, row)
+	}
+
+	coverage.EmptyPlots = coverage.TotalPlots - coverage.PlantedPlots
+	coverage.Coverage = float64(coverage.PlantedPlots) / float64(coverage.TotalPlots)
+
+	run := 0
+	for _, wp := range path {
+		if !region.contains(wp.X, wp.Y) || grid[wp.Y-1][wp.X-1] > 0 {
+			run = 0
+			continue
+		}
+
+		run++
+		if run > coverage.LongestEmptyRun {
+			coverage.LongestEmptyRun = run
+		}
+	}
+
+	return coverage
+}
+
+// validateDroneRequest checks every field of the drone profile is given
+func validateDroneRequest(req generated.DroneRequest) error {
+	if req.Model == "" {
+		return ErrEmptyBuilder("model")
+	}
+
+	if req.MaxRange <= 0 {
+		return ErrNegativeZeroBuilder("max_range")
+	}
+
+	if req.MaxAltitude <= 0 {
+		return ErrNegativeZeroBuilder("max_altitude")
+	}
+
+	if req.CruiseSpeed <= 0 {
+		return ErrNegativeZeroBuilder("cruise_speed")
+	}
+
+	if req.ClimbRate <= 0 {
+		return ErrNegativeZeroBuilder("climb_rate")
+	}
+
+	if req.BatteryCapacity <= 0 {
+		return ErrNegativeZeroBuilder("battery_capacity")
+	}
+
+	return nil
+}
+
+func buildDroneResponse(drone repository.Drone) generated.DroneResponse {
+	return generated.DroneResponse{
+		Id:              drone.Id,
+		Model:           drone.Model,
+		MaxRange:        drone.MaxRange,
+		MaxAltitude:     drone.MaxAltitude,
+		CruiseSpeed:     drone.CruiseSpeed,
+		ClimbRate:       drone.ClimbRate,
+		BatteryCapacity: drone.BatteryCapacity,
+	}
+}
+
+func buildSortieResponses(sorties []flightSortie) *[]generated.EstateSortieResponse {
+	resp := make([]generated.EstateSortieResponse, 0, len(sorties))
+	for _, sortie := range sorties {
+		resp = append(resp, generated.EstateSortieResponse{
+			ResumeX:    sortie.resume.X,
+			ResumeY:    sortie.resume.Y,
+			StopX:      sortie.stop.X,
+			StopY:      sortie.stop.Y,
+			Distance:   sortie.cost.distance,
+			FlightTime: sortie.cost.time,
+			Energy:     sortie.cost.energy,
+		})
+	}
+
+	return &resp
+}
+
+func buildCeilingErrorResponse(ceiling int, violations []flightWaypoint) generated.CeilingErrorResponse {
+	plots := make([]generated.CeilingViolationResponse, 0, len(violations))
+	for _, wp := range violations {
+		plots = append(plots, generated.CeilingViolationResponse{
+			X:        wp.X,
+			Y:        wp.Y,
+			Altitude: wp.Altitude - wp.Ground,
+		})
+	}
+
+	return generated.CeilingErrorResponse{
+		Message: ErrCeilingExceeded.Error(),
+		Ceiling: ceiling,
+		Plots:   plots,
+	}
+}
+
+func buildFlightModeEstimate(cost flightCost) generated.EstateFlightModeEstimate {
+	return generated.EstateFlightModeEstimate{
+		Distance:   cost.distance,
+		FlightTime: cost.time,
+		Energy:     cost.energy,
+	}
+}
+
+// selectInspectionTrees keeps the trees of the request ids that are within
+// the request heights. Every id must be a tree of the estate.
+func selectInspectionTrees(trees []repository.EstateTree, req generated.InspectionPlanRequest) ([]repository.EstateTree, error) {
+	var ids map[string]bool
+	if req.TreeIds != nil {
+		ids = make(map[string]bool, len(*req.TreeIds))
+		for _, id := range *req.TreeIds {
+			ids[id] = true
+		}
+	}
+
+	selected := make([]repository.EstateTree, 0, len(trees))
+	for _, tree := range trees {
+		if ids != nil {
+			if !ids[tree.Id] {
+				continue
+			}
+
+			delete(ids, tree.Id)
+		}
+
+		if (req.MinHeight != nil && tree.Height < *req.MinHeight) || (req.MaxHeight != nil && tree.Height > *req.MaxHeight) {
+			continue
+		}
+
+		selected = append(selected, tree)
+	}
+
+	if len(ids) > 0 {
+		return nil, ErrNotFoundBuilder("tree")
+	}
+
+	return selected, nil
+}
+
+func buildInspectionWaypoints(path []flightWaypoint, treeIds map[[2]int]string) []generated.InspectionWaypointResponse {
+	waypoints := make([]generated.InspectionWaypointResponse, 0, len(path))
+	for _, wp := range path {
+		waypoint := generated.InspectionWaypointResponse{
+			X:        wp.X,
+			Y:        wp.Y,
+			Altitude: wp.Altitude,
+			Transit:  wp.Transit,
+		}
+
+		if id, ok := treeIds[[2]int{wp.X, wp.Y}]; ok && !wp.Transit {
+			waypoint.TreeId = &id
+		}
+
+		waypoints = append(waypoints, waypoint)
+	}
+
+	return waypoints
+}
+
+// validateFlightMode checks the drone plan mode and the swath, which only the
+// coverage mode takes. The plan follows the canopy one row at a time by
+// default.
+func validateFlightMode(modeParam *string, swathParam *int) (mode string, swath int, err error) {
+	mode, swath = flightModeCanopyFollowing, 1
+	if modeParam != nil {
+		mode = *modeParam
+	}
+
+	if mode != flightModeCanopyFollowing && mode != flightModeConstantAltitude && mode != flightModeCoverage {
+		return "", 0, ErrInvalidFlightMode
+	}
+
+	if swathParam != nil {
+		if mode != flightModeCoverage {
+			return "", 0, ErrParamConflictBuilder("swath", mode)
+		}
+
+		if *swathParam <= 0 {
+			return "", 0, ErrNegativeZeroBuilder("swath")
+		}
+
+		swath = *swathParam
+	}
+
+	return mode, swath, nil
+}
+
+// hashTrees fingerprints the trees by their plot and height, whatever order
+// they are listed in
+func hashTrees(trees []repository.EstateTree) string {
+	plots := make([]string, 0, len(trees))
+	for _, tree := range trees {
+		plots = append(plots, fmt.Sprintf("%d,%d,%d", tree.X, tree.Y, tree.Height))
+	}
+	sort.Strings(plots)
+
+	sum := sha256.Sum256([]byte(strings.Join(plots, "\n")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func buildPlanWaypoints(path []flightWaypoint) []repository.PlanWaypoint {
+	waypoints := make([]repository.PlanWaypoint, 0, len(path))
+	for _, wp := range path {
+		waypoints = append(waypoints, repository.PlanWaypoint{
+			X:        wp.X,
+			Y:        wp.Y,
+			Altitude: wp.Altitude,
+			Transit:  wp.Transit,
+		})
+	}
+
+	return waypoints
+}
+
+// buildSnapshotResponse marks the snapshot stale when the trees of the estate
+// no longer hash the same
+func buildSnapshotResponse(snapshot repository.DronePlanSnapshot, currentTreeHash string) generated.DronePlanSnapshotResponse {
+	waypoints := make([]generated.PlanWaypointResponse, 0, len(snapshot.Waypoints))
+	for _, wp := range snapshot.Waypoints {
+		waypoints = append(waypoints, generated.PlanWaypointResponse{
+			X:        wp.X,
+			Y:        wp.Y,
+			Altitude: wp.Altitude,
+			Transit:  wp.Transit,
+		})
+	}
+
+	return generated.DronePlanSnapshotResponse{
+		Version:         snapshot.Version,
+		Mode:            snapshot.Mode,
+		TreeHash:        snapshot.TreeHash,
+		CurrentTreeHash: currentTreeHash,
+		Stale:           snapshot.TreeHash != currentTreeHash,
+		Distance:        snapshot.Distance,
+		FlightTime:      snapshot.FlightTime,
+		Energy:          snapshot.Energy,
+		Waypoints:       waypoints,
+		CreatedAt:       snapshot.CreatedAt,
+	}
+}
+
+func buildMissionResponse(mission repository.Mission) generated.MissionResponse {
+	resp := generated.MissionResponse{
+		Id:              mission.Id,
+		EstateId:        mission.EstateId,
+		SnapshotVersion: mission.SnapshotVersion,
+		Status:          mission.Status,
+		CreatedAt:       mission.CreatedAt,
+	}
+
+	if mission.DroneId.Valid {
+		resp.DroneId = &mission.DroneId.String
+	}
+
+	if mission.AbortReason.Valid {
+		resp.AbortReason = &mission.AbortReason.String
+	}
+
+	if mission.StartedAt.Valid {
+		resp.StartedAt = &mission.StartedAt.Time
+	}
+
+	if mission.EndedAt.Valid {
+		resp.EndedAt = &mission.EndedAt.Time
+	}
+
+	return resp
+}
+
+func buildDeviationResponse(report deviationReport) generated.MissionDeviationResponse {
+	resp := generated.MissionDeviationResponse{
+		Points:               report.points,
+		MaxAltitudeDeviation: report.maxAltitudeDeviation,
+		OffPlanPoints:        report.offPlan,
+		PlannedDistance:      report.plannedDistance,
+		ActualDistance:       report.actualDistance,
+		BatteryUsed:          report.batteryUsed,
+		SkippedPlots:         buildPlotResponses(report.skipped),
+	}
+
+	if report.maxDeviationAt != nil {
+		resp.MaxDeviationPlot = &generated.PlotResponse{X: report.maxDeviationAt[0], Y: report.maxDeviationAt[1]}
+	}
+
+	return resp
+}
+
+func buildPlotResponses(plots [][2]int) []generated.PlotResponse {
+	resp := make([]generated.PlotResponse, 0, len(plots))
+	for _, plot := range plots {
+		resp = append(resp, generated.PlotResponse{X: plot[0], Y: plot[1]})
+	}
+
+	return resp
+}
+
+func buildTelemetryEvent(points []repository.TelemetryPoint) generated.MissionTelemetryEvent {
+	event := generated.MissionTelemetryEvent{
+		Points: make([]generated.TelemetryPointResponse, 0, len(points)),
+	}
+
+	for _, point := range points {
+		event.Points = append(event.Points, generated.TelemetryPointResponse{
+			Timestamp: point.RecordedAt,
+			X:         point.X,
+			Y:         point.Y,
+			Altitude:  point.Altitude,
+			Battery:   point.Battery,
+		})
+	}
+
+	return event
+}
+
+func buildObservationResponse(observation repository.TreeObservation) generated.TreeObservationResponse {
+	resp := generated.TreeObservationResponse{
+		Id:          observation.Id,
+		TreeId:      observation.TreeId,
+		X:           observation.X,
+		Y:           observation.Y,
+		HealthScore: observation.HealthScore,
+		Pests:       observation.Pests,
+		ObservedAt:  observation.ObservedAt,
+		CreatedAt:   observation.CreatedAt,
+	}
+
+	if resp.Pests == nil {
+		resp.Pests = []string{}
+	}
+
+	if observation.MissionId.Valid {
+		resp.MissionId = &observation.MissionId.String
+	}
+
+	if observation.SuspectedDisease.Valid {
+		resp.SuspectedDisease = &observation.SuspectedDisease.String
+	}
+
+	if observation.FruitCount.Valid {
+		fruitCount := int(observation.FruitCount.Int64)
+		resp.FruitCount = &fruitCount
+	}
+
+	if observation.Notes.Valid {
+		resp.Notes = &observation.Notes.String
+	}
+
+	return resp
+}
+
+func buildSpeciesResponse(species repository.Species) generated.SpeciesResponse {
+	return generated.SpeciesResponse{
+		Id:            species.Id,
+		Name:          species.Name,
+		MaxHeight:     species.MaxHeight,
+		GrowthProfile: species.GrowthProfile,
+	}
+}
+
+func buildTreeResponse(tree repository.EstateTree) generated.TreeResponse {
+	resp := generated.TreeResponse{
+		Id:     tree.Id,
+		X:      tree.X,
+		Y:      tree.Y,
+		Height: tree.Height,
+		Status: tree.Status,
+	}
+
+	if tree.SpeciesId.Valid {
+		resp.SpeciesId = &tree.SpeciesId.String
+	}
+
+	return resp
+}
+
+func buildTreeStatusChangeResponse(change repository.TreeStatusChange) generated.TreeStatusChangeResponse {
+	resp := generated.TreeStatusChangeResponse{
+		To:        change.To,
+		ChangedAt: change.ChangedAt,
+	}
+
+	if change.From.Valid {
+		resp.From = &change.From.String
+	}
+
+	if change.Reason.Valid {
+		resp.Reason = &change.Reason.String
+	}
 
-	return float64(data[mid]+data[mid+1]) / 2
+	return resp
 }
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/naufalfmm/plantation-drone-api/generated"
+	"github.com/naufalfmm/plantation-drone-api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindMedian(t *testing.T) {
+	t.Run("Return zero when there is no data", func(t *testing.T) {
+		assert.Equal(t, 0., findMedian(nil))
+	})
+
+	t.Run("Return the middle when data is odd", func(t *testing.T) {
+		assert.Equal(t, 3., findMedian([]int{5, 1, 3}))
+	})
+
+	t.Run("Return the average of the two middles when data is even", func(t *testing.T) {
+		assert.Equal(t, 2.5, findMedian([]int{4, 1, 3, 2}))
+		assert.Equal(t, 7.5, findMedian([]int{11, 4}))
+	})
+}
+
+func TestBuildCoverage(t *testing.T) {
+	grid := [][]int{
+		{0, 0, 0, 4},
+		{0, 0, 0, 0},
+		{0, 7, 0, 0},
+	}
+	path := buildFlightPath(newAirspace(grid, nil, nil))
+
+	t.Run("Measure the whole estate", func(t *testing.T) {
+		coverage := buildCoverage(grid, plotRegion{minX: 1, maxX: 4, minY: 1, maxY: 3}, path)
+
+		assert.Equal(t, 12, coverage.TotalPlots)
+		assert.Equal(t, 2, coverage.PlantedPlots)
+		assert.Equal(t, 10, coverage.EmptyPlots)
+		assert.Equal(t, 5, coverage.LongestEmptyRun)
+		assert.Equal(t, 0.25, coverage.Rows[0].Density)
+		assert.Equal(t, 0., coverage.Rows[1].Density)
+	})
+
+	t.Run("Break the empty run when the path leaves the region", func(t *testing.T) {
+		coverage := buildCoverage(grid, plotRegion{minX: 1, maxX: 2, minY: 1, maxY: 3}, path)
+
+		assert.Equal(t, 6, coverage.TotalPlots)
+		assert.Equal(t, 1, coverage.PlantedPlots)
+		assert.Equal(t, 3, coverage.LongestEmptyRun)
+		assert.Equal(t, []int{1, 2, 3}, []int{coverage.Rows[0].Row, coverage.Rows[1].Row, coverage.Rows[2].Row})
+	})
+}
+
+func TestHashTrees(t *testing.T) {
+	trees := []repository.EstateTree{
+		{Id: "a", X: 1, Y: 1, Height: 10},
+		{Id: "b", X: 2, Y: 1, Height: 5},
+	}
+
+	t.Run("Hash the same trees the same in any order", func(t *testing.T) {
+		assert.Equal(t, hashTrees(trees), hashTrees([]repository.EstateTree{trees[1], trees[0]}))
+	})
+
+	t.Run("Hash differently once a tree grows", func(t *testing.T) {
+		grown := []repository.EstateTree{trees[0], {Id: "b", X: 2, Y: 1, Height: 6}}
+
+		assert.NotEqual(t, hashTrees(trees), hashTrees(grown))
+	})
+
+	t.Run("Hash differently once a tree is planted", func(t *testing.T) {
+		assert.NotEqual(t, hashTrees(trees[:1]), hashTrees(trees))
+	})
+}
+
+func TestBuildSpeciesStatGroups(t *testing.T) {
+	catalogue := []repository.Species{
+		{Id: "a", Name: "Durian"},
+		{Id: "b", Name: "Oil Palm"},
+		{Id: "c", Name: "Rubber"},
+	}
+
+	t.Run("Group the trees in catalogue order with the unspecified ones last", func(t *testing.T) {
+		trees := []repository.EstateTree{
+			{X: 1, Y: 1, Height: 4, SpeciesId: sql.NullString{String: "c", Valid: true}},
+			{X: 2, Y: 1, Height: 6},
+			{X: 3, Y: 1, Height: 8, SpeciesId: sql.NullString{String: "a", Valid: true}},
+			{X: 1, Y: 2, Height: 2, SpeciesId: sql.NullString{String: "c", Valid: true}},
+		}
+
+		groups := buildSpeciesStatGroups(trees, catalogue)
+
+		durian, rubber := [2]string{"a", "Durian"}, [2]string{"c", "Rubber"}
+		assert.Equal(t, []generated.EstateSpeciesStatGroupResponse{
+			{SpeciesId: &durian[0], Name: &durian[1], Count: 1, Max: 8, Min: 8, Median: 8},
+			{SpeciesId: &rubber[0], Name: &rubber[1], Count: 2, Max: 4, Min: 2, Median: 3},
+			{Count: 1, Max: 6, Min: 6, Median: 6},
+		}, groups)
+	})
+
+	t.Run("Return no group when there is no tree", func(t *testing.T) {
+		assert.Equal(t, []generated.EstateSpeciesStatGroupResponse{}, buildSpeciesStatGroups(nil, catalogue))
+	})
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// hubBuffer is the number of events a viewer may fall behind before the hub
+// starts dropping the telemetry for it
+const hubBuffer = 16
+
+// streamHeartbeat is how often an idle stream sends a comment, so the proxies
+// in between do not close it
+var streamHeartbeat = 15 * time.Second
+
+const (
+	missionEventStatus    = "status"
+	missionEventTelemetry = "telemetry"
+)
+
+type missionEvent struct {
+	name string
+	data interface{}
+}
+
+// MissionHub fans the updates of the missions out to everyone streaming them.
+// It lives in the process, so a viewer only hears about the updates the same
+// instance ingests.
+type MissionHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan missionEvent]struct{}
+}
+
+func NewMissionHub() *MissionHub {
+	return &MissionHub{
+		subscribers: map[string]map[chan missionEvent]struct{}{},
+	}
+}
+
+// subscribe listens to the events of a mission until the returned function is
+// called, or until the hub closes the channel because the viewer fell behind.
+// Nothing is ever published on a nil hub, so its viewers get a channel that
+// never receives.
+func (h *MissionHub) subscribe(missionId string) (<-chan missionEvent, func()) {
+	if h == nil {
+		return nil, func() {}
+	}
+
+	events := make(chan missionEvent, hubBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subscribers[missionId] == nil {
+		h.subscribers[missionId] = map[chan missionEvent]struct{}{}
+	}
+	h.subscribers[missionId][events] = struct{}{}
+
+	return events, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		h.remove(missionId, events)
+	}
+}
+
+// remove forgets the viewer, once, and closes its channel. The caller holds
+// the lock.
+func (h *MissionHub) remove(missionId string, events chan missionEvent) {
+	if _, ok := h.subscribers[missionId][events]; !ok {
+		return
+	}
+
+	delete(h.subscribers[missionId], events)
+	if len(h.subscribers[missionId]) == 0 {
+		delete(h.subscribers, missionId)
+	}
+
+	close(events)
+}
+
+// publish never waits for a viewer, so a slow connection cannot hold up the
+// ingestion. The telemetry is dropped for a viewer whose buffer is full, but a
+// status never is: the viewer is closed instead, so it reconnects and reads
+// the mission again. A nil hub has no viewers.
+func (h *MissionHub) publish(missionId string, event missionEvent) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for events := range h.subscribers[missionId] {
+		select {
+		case events <- event:
+		default:
+			if event.name == missionEventStatus {
+				h.remove(missionId, events)
+			}
+		}
+	}
+}
+
+// writeMissionEvent writes the event as a Server-Sent Event and flushes it to
+// the viewer right away
+func writeMissionEvent(res *echo.Response, event missionEvent) error {
+	data, err := json.Marshal(event.data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.name, data); err != nil {
+		return err
+	}
+
+	res.Flush()
+
+	return nil
+}
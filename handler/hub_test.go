@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissionHub(t *testing.T) {
+	t.Run("Publish the event to every viewer of the mission", func(t *testing.T) {
+		hub := NewMissionHub()
+
+		first, unsubscribeFirst := hub.subscribe("mission-1")
+		defer unsubscribeFirst()
+
+		second, unsubscribeSecond := hub.subscribe("mission-1")
+		defer unsubscribeSecond()
+
+		other, unsubscribeOther := hub.subscribe("mission-2")
+		defer unsubscribeOther()
+
+		event := missionEvent{name: missionEventStatus, data: "completed"}
+		hub.publish("mission-1", event)
+
+		assert.Equal(t, event, <-first)
+		assert.Equal(t, event, <-second)
+		assert.Len(t, other, 0)
+	})
+
+	t.Run("Stop publishing to a viewer that unsubscribed", func(t *testing.T) {
+		hub := NewMissionHub()
+
+		events, unsubscribe := hub.subscribe("mission-1")
+		unsubscribe()
+
+		hub.publish("mission-1", missionEvent{name: missionEventStatus})
+
+		assert.Len(t, events, 0)
+		assert.Empty(t, hub.subscribers)
+	})
+
+	t.Run("Drop the events of a viewer that falls behind", func(t *testing.T) {
+		hub := NewMissionHub()
+
+		events, unsubscribe := hub.subscribe("mission-1")
+		defer unsubscribe()
+
+		for i := 0; i < hubBuffer+1; i++ {
+			hub.publish("mission-1", missionEvent{name: missionEventTelemetry, data: i})
+		}
+
+		assert.Len(t, events, hubBuffer)
+		assert.Equal(t, 0, (<-events).data)
+	})
+
+	t.Run("Close a viewer that falls behind on a status", func(t *testing.T) {
+		hub := NewMissionHub()
+
+		events, unsubscribe := hub.subscribe("mission-1")
+		defer unsubscribe()
+
+		for i := 0; i < hubBuffer; i++ {
+			hub.publish("mission-1", missionEvent{name: missionEventTelemetry, data: i})
+		}
+		hub.publish("mission-1", missionEvent{name: missionEventStatus, data: "completed"})
+
+		received := 0
+		for range events {
+			received++
+		}
+
+		assert.Equal(t, hubBuffer, received)
+		assert.Empty(t, hub.subscribers)
+	})
+
+	t.Run("Publish nothing on a nil hub", func(t *testing.T) {
+		var hub *MissionHub
+
+		assert.NotPanics(t, func() {
+			hub.publish("mission-1", missionEvent{name: missionEventStatus})
+		})
+	})
+
+	t.Run("Subscribe to nothing on a nil hub", func(t *testing.T) {
+		var hub *MissionHub
+
+		assert.NotPanics(t, func() {
+			events, unsubscribe := hub.subscribe("mission-1")
+			defer unsubscribe()
+
+			assert.Nil(t, events)
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package handler
+
+// hopDistance estimates how far the drone flies from one plot to another,
+// without the detours around the no-fly plots
+func (s airspace) hopDistance(from, to [2]int) int {
+	return plotDistance*(abs(from[0]-to[0])+abs(from[1]-to[1])) + abs(s.altitude(from[0], from[1])-s.altitude(to[0], to[1]))
+}
+
+// orderInspection orders the plots to inspect with the nearest neighbour
+// heuristic from the start, then shortens the route with 2-opt. The route
+// ends back at the start when the drone has to go back there.
+func (s airspace) orderInspection(start [2]int, plots [][2]int, back bool) [][2]int {
+	route := make([][2]int, 0, len(plots)+2)
+	route = append(route, start)
+
+	left := append([][2]int(nil), plots...)
+	for len(left) > 0 {
+		cur, nearest := route[len(route)-1], 0
+		for i := range left {
+			if s.hopDistance(cur, left[i]) < s.hopDistance(cur, left[nearest]) {
+				nearest = i
+			}
+		}
+
+		route = append(route, left[nearest])
+		left = append(left[:nearest], left[nearest+1:]...)
+	}
+
+	if back {
+		route = append(route, start)
+	}
+
+	// Reversing route[i:k+1] swaps the edges before i and after k for the
+	// edges from i-1 to k and from i to k+1, which the open route does not
+	// have after its last plot
+	last, end := len(route)-1, len(route)-1
+	if back {
+		end--
+	}
+
+	for improved := true; improved; {
+		improved = false
+
+		for i := 1; i < end; i++ {
+			for k := i + 1; k <= end; k++ {
+				before := s.hopDistance(route[i-1], route[i])
+				after := s.hopDistance(route[i-1], route[k])
+				if k < last {
+					before += s.hopDistance(route[k], route[k+1])
+					after += s.hopDistance(route[i], route[k+1])
+				}
+
+				if after < before {
+					for l, r := i, k; l < r; l, r = l+1, r-1 {
+						route[l], route[r] = route[r], route[l]
+					}
+
+					improved = true
+				}
+			}
+		}
+	}
+
+	return route[1 : len(plots)+1]
+}
+
+// reachable splits the plots into the ones the drone can fly to from the
+// start without crossing a no-fly plot and the ones it cannot
+func (s airspace) reachable(start [2]int, plots [][2]int) (reached, unreached [][2]int) {
+	for _, plot := range plots {
+		if s.flyable(plot[0], plot[1]) && (plot == start || s.route(start[0], start[1], plot[0], plot[1]) != nil) {
+			reached = append(reached, plot)
+			continue
+		}
+
+		unreached = append(unreached, plot)
+	}
+
+	return
+}
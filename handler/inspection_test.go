@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/naufalfmm/plantation-drone-api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderInspection(t *testing.T) {
+	t.Run("Visit the nearest plot first", func(t *testing.T) {
+		space := newAirspace([][]int{make([]int, 10)}, nil, nil)
+
+		assert.Equal(t, [][2]int{{2, 1}, {5, 1}, {9, 1}}, space.orderInspection([2]int{1, 1}, [][2]int{{9, 1}, {2, 1}, {5, 1}}, false))
+	})
+
+	t.Run("Shorten the nearest neighbour route with 2-opt", func(t *testing.T) {
+		space := newAirspace([][]int{make([]int, 10)}, nil, nil)
+
+		// The nearest neighbour goes 5 -> 6 -> 3 -> 10, 110m instead of 90m
+		assert.Equal(t, [][2]int{{3, 1}, {6, 1}, {10, 1}}, space.orderInspection([2]int{5, 1}, [][2]int{{6, 1}, {3, 1}, {10, 1}}, false))
+	})
+
+	t.Run("Go back to the start", func(t *testing.T) {
+		space := newAirspace([][]int{make([]int, 3), make([]int, 3), make([]int, 3)}, nil, nil)
+
+		assert.Equal(t, [][2]int{{1, 3}, {3, 3}, {3, 1}}, space.orderInspection([2]int{1, 1}, [][2]int{{3, 3}, {1, 3}, {3, 1}}, true))
+	})
+
+	t.Run("Weigh the climb to the next plot", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 0, 30, 0}}, nil, nil)
+
+		// (3, 1) is nearer but 30m higher than (4, 1) from (2, 1)
+		assert.Equal(t, [][2]int{{4, 1}, {3, 1}}, space.orderInspection([2]int{2, 1}, [][2]int{{3, 1}, {4, 1}}, false))
+	})
+}
+
+func TestBuildFlightPathVisitingStops(t *testing.T) {
+	space := newAirspace([][]int{{0, 10, 0, 20}}, nil, nil).visiting([][2]int{{4, 1}, {2, 1}})
+
+	path := buildFlightPath(space)
+
+	assert.Equal(t, []flightWaypoint{
+		{X: 4, Y: 1, Altitude: 21, Distance: 21},
+		{X: 3, Y: 1, Altitude: 1, Distance: 51, Transit: true},
+		{X: 2, Y: 1, Altitude: 11, Distance: 71},
+	}, path)
+}
+
+func TestReachable(t *testing.T) {
+	t.Run("Reach the plots around a no-fly plot", func(t *testing.T) {
+		space := newAirspace([][]int{make([]int, 3), make([]int, 3)}, []repository.EstateObstacle{{X: 2, Y: 1, NoFly: true}}, nil)
+
+		reached, unreached := space.reachable([2]int{1, 1}, [][2]int{{1, 1}, {3, 1}, {2, 1}})
+
+		assert.Equal(t, [][2]int{{1, 1}, {3, 1}}, reached)
+		assert.Equal(t, [][2]int{{2, 1}}, unreached)
+	})
+
+	t.Run("Leave out the plots walled off by no-fly plots", func(t *testing.T) {
+		space := newAirspace([][]int{make([]int, 3)}, []repository.EstateObstacle{{X: 2, Y: 1, NoFly: true}}, nil).withHome(0, 1)
+
+		reached, unreached := space.reachable([2]int{0, 1}, [][2]int{{1, 1}, {3, 1}})
+
+		assert.Equal(t, [][2]int{{1, 1}}, reached)
+		assert.Equal(t, [][2]int{{3, 1}}, unreached)
+	})
+}
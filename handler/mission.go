@@ -0,0 +1,39 @@
+package handler
+
+const (
+	missionStatusScheduled  = "scheduled"
+	missionStatusInProgress = "in_progress"
+	missionStatusCompleted  = "completed"
+	missionStatusAborted    = "aborted"
+)
+
+// missionTransitions lists the statuses every status may move to. A mission
+// may be aborted before it starts, but once it ends it stays ended.
+var missionTransitions = map[string][]string{
+	missionStatusScheduled:  {missionStatusInProgress, missionStatusAborted},
+	missionStatusInProgress: {missionStatusCompleted, missionStatusAborted},
+}
+
+func isMissionStatus(status string) bool {
+	switch status {
+	case missionStatusScheduled, missionStatusInProgress, missionStatusCompleted, missionStatusAborted:
+		return true
+	}
+
+	return false
+}
+
+func canMoveMission(from, to string) bool {
+	for _, next := range missionTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMissionEnded tells whether a mission has no status left to move to
+func isMissionEnded(status string) bool {
+	return len(missionTransitions[status]) == 0
+}
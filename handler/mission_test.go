@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanMoveMission(t *testing.T) {
+	t.Run("Move a scheduled mission to in progress or aborted", func(t *testing.T) {
+		assert.True(t, canMoveMission(missionStatusScheduled, missionStatusInProgress))
+		assert.True(t, canMoveMission(missionStatusScheduled, missionStatusAborted))
+		assert.False(t, canMoveMission(missionStatusScheduled, missionStatusCompleted))
+	})
+
+	t.Run("End a mission in progress", func(t *testing.T) {
+		assert.True(t, canMoveMission(missionStatusInProgress, missionStatusCompleted))
+		assert.True(t, canMoveMission(missionStatusInProgress, missionStatusAborted))
+		assert.False(t, canMoveMission(missionStatusInProgress, missionStatusScheduled))
+	})
+
+	t.Run("Keep an ended mission ended", func(t *testing.T) {
+		assert.False(t, canMoveMission(missionStatusCompleted, missionStatusAborted))
+		assert.False(t, canMoveMission(missionStatusAborted, missionStatusInProgress))
+	})
+}
+
+func TestIsMissionEnded(t *testing.T) {
+	t.Run("End a completed or aborted mission only", func(t *testing.T) {
+		assert.True(t, isMissionEnded(missionStatusCompleted))
+		assert.True(t, isMissionEnded(missionStatusAborted))
+		assert.False(t, isMissionEnded(missionStatusScheduled))
+		assert.False(t, isMissionEnded(missionStatusInProgress))
+	})
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	"github.com/naufalfmm/plantation-drone-api/repository"
+)
+
+const (
+	// heatmapPlotPixels is the preferred edge of a plot in the heatmap image,
+	// shrunk down for big estates so the image stays within heatmapMaxPixels
+	heatmapPlotPixels = 10
+	heatmapMaxPixels  = 2000
+
+	svgPlotPixels = 40
+	svgMargin     = 20
+)
+
+var (
+	heatmapEmptyColor  = color.RGBA{R: 120, G: 85, B: 60, A: 255}
+	heatmapLowestColor = color.RGBA{R: 255, G: 220, B: 80, A: 255}
+	heatmapTallColor   = color.RGBA{R: 0, G: 100, B: 0, A: 255}
+)
+
+// heatmapColor scales a tree height from yellow for the shortest to dark green
+// for the tallest tree of the estate. Empty plots are drawn as soil.
+func heatmapColor(height, maxHeight int) color.RGBA {
+	if height <= 0 || maxHeight <= 0 {
+		return heatmapEmptyColor
+	}
+
+	ratio := float64(height) / float64(maxHeight)
+	blend := func(from, to uint8) uint8 {
+		return uint8(float64(from) + (float64(to)-float64(from))*ratio)
+	}
+
+	return color.RGBA{
+		R: blend(heatmapLowestColor.R, heatmapTallColor.R),
+		G: blend(heatmapLowestColor.G, heatmapTallColor.G),
+		B: blend(heatmapLowestColor.B, heatmapTallColor.B),
+		A: 255,
+	}
+}
+
+func heatmapPlotSize(length, width int) int {
+	longest := length
+	if width > longest {
+		longest = width
+	}
+
+	size := heatmapMaxPixels / longest
+	if size > heatmapPlotPixels {
+		size = heatmapPlotPixels
+	}
+	if size < 1 {
+		size = 1
+	}
+
+	return size
+}
+
+// renderHeatmapPng draws the height grid with x to the right and y downward,
+// so plot (1, 1) is the top left corner of the image
+func renderHeatmapPng(grid [][]int, maxHeight int) ([]byte, error) {
+	width := len(grid)
+	length := 0
+	if width > 0 {
+		length = len(grid[0])
+	}
+
+	size := heatmapPlotSize(length, width)
+	img := image.NewRGBA(image.Rect(0, 0, length*size, width*size))
+
+	for y, row := range grid {
+		for x, height := range row {
+			plot := image.Rect(x*size, y*size, (x+1)*size, (y+1)*size)
+			draw.Draw(img, plot, &image.Uniform{C: heatmapColor(height, maxHeight)}, image.Point{}, draw.Src)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// svgPlotCenter is the center of the plot in the SVG canvas, laid out like
+// the heatmap with plot (1, 1) on the top left corner
+func svgPlotCenter(x, y int) (cx, cy int) {
+	return svgMargin + (x-1)*svgPlotPixels + svgPlotPixels/2, svgMargin + (y-1)*svgPlotPixels + svgPlotPixels/2
+}
+
+// renderEstateSvg draws the plot grid, the obstacles, the trees sized and
+// coloured by their height, and the drone flight path annotated with its
+// altitude above every plot. The rest point is marked when the drone cannot
+// finish the path.
+func renderEstateSvg(grid [][]int, maxHeight int, obstacles []repository.EstateObstacle, path []flightWaypoint, rest flightWaypoint, hasRest bool) []byte {
+	width := len(grid)
+	length := 0
+	if width > 0 {
+		length = len(grid[0])
+	}
+
+	canvasWidth := length*svgPlotPixels + 2*svgMargin
+	canvasHeight := width*svgPlotPixels + 2*svgMargin
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, canvasWidth, canvasHeight, canvasWidth, canvasHeight)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#ffffff"/>`, canvasWidth, canvasHeight)
+
+	fmt.Fprint(&buf, `<g stroke="#cccccc" fill="none">`)
+	for y := 1; y <= width; y++ {
+		for x := 1; x <= length; x++ {
+			fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d"/>`, svgMargin+(x-1)*svgPlotPixels, svgMargin+(y-1)*svgPlotPixels, svgPlotPixels, svgPlotPixels)
+		}
+	}
+	fmt.Fprint(&buf, `</g>`)
+
+	fmt.Fprint(&buf, `<g>`)
+	for _, obstacle := range obstacles {
+		fill := "#7f7f7f"
+		if obstacle.NoFly {
+			fill = "#d62728"
+		}
+
+		fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" fill-opacity="0.4"><title>(%d, %d) %s %dm no-fly=%t</title></rect>`, svgMargin+(obstacle.X-1)*svgPlotPixels, svgMargin+(obstacle.Y-1)*svgPlotPixels, svgPlotPixels, svgPlotPixels, fill, obstacle.X, obstacle.Y, html.EscapeString(obstacle.Description), obstacle.Height, obstacle.NoFly)
+	}
+	fmt.Fprint(&buf, `</g>`)
+
+	fmt.Fprint(&buf, `<g>`)
+	for y, row := range grid {
+		for x, height := range row {
+			if height <= 0 {
+				continue
+			}
+
+			cx, cy := svgPlotCenter(x+1, y+1)
+			radius := float64(svgPlotPixels) / 2 * (0.3 + 0.6*float64(height)/float64(maxHeight))
+			fmt.Fprintf(&buf, `<circle cx="%d" cy="%d" r="%.1f" fill="%s"><title>(%d, %d) %dm</title></circle>`, cx, cy, radius, svgColor(heatmapColor(height, maxHeight)), x+1, y+1, height)
+		}
+	}
+	fmt.Fprint(&buf, `</g>`)
+
+	if len(path) > 0 {
+		fmt.Fprint(&buf, `<polyline fill="none" stroke="#1e6fd9" stroke-width="2" points="`)
+		for i, wp := range path {
+			cx, cy := svgPlotCenter(wp.X, wp.Y)
+			if i > 0 {
+				fmt.Fprint(&buf, " ")
+			}
+			fmt.Fprintf(&buf, "%d,%d", cx, cy)
+		}
+		fmt.Fprint(&buf, `"/>`)
+
+		fmt.Fprint(&buf, `<g font-family="sans-serif" font-size="9" fill="#1e6fd9">`)
+		for _, wp := range path {
+			cx, cy := svgPlotCenter(wp.X, wp.Y)
+			fmt.Fprintf(&buf, `<text x="%d" y="%d">%dm</text>`, cx-svgPlotPixels/2+2, cy-svgPlotPixels/2+10, wp.Altitude)
+		}
+		fmt.Fprint(&buf, `</g>`)
+
+		cx, cy := svgPlotCenter(path[0].X, path[0].Y)
+		fmt.Fprintf(&buf, `<circle cx="%d" cy="%d" r="4" fill="#1e6fd9"><title>takeoff</title></circle>`, cx, cy)
+	}
+
+	if hasRest {
+		cx, cy := svgPlotCenter(rest.X, rest.Y)
+		fmt.Fprintf(&buf, `<circle cx="%d" cy="%d" r="8" fill="none" stroke="#d62728" stroke-width="3"><title>rest (%d, %d)</title></circle>`, cx, cy, rest.X, rest.Y)
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" font-family="sans-serif" font-size="10" fill="#d62728">rest</text>`, cx+10, cy+14)
+	}
+
+	fmt.Fprint(&buf, `</svg>`)
+
+	return buf.Bytes()
+}
@@ -0,0 +1,30 @@
+package handler
+
+// emptyPlots lists the plots without a tree or a standing obstacle that the
+// drone may fly over, in the serpentine order of the flight path
+func (s airspace) emptyPlots() [][2]int {
+	plots := [][2]int{}
+	s.eachPlot(func(x, y int) {
+		if s.heightAt(x, y) == 0 && s.flyable(x, y) {
+			plots = append(plots, [2]int{x, y})
+		}
+	})
+
+	return plots
+}
+
+// planSeeding drops the seeds on every empty plot the path over them visits,
+// refilling at home like planSpray refills the tank. The path comes from the
+// airspace visiting its emptyPlots, so the drone keeps its altitude above
+// every plot it passes over and still climbs over the trees between two empty
+// plots. It returns false when a plot needs more seeds than the drone carries.
+func planSeeding(space airspace, path []flightWaypoint, seeds, capacity int) (mission sprayMission, ok bool) {
+	plots := space.emptyPlots()
+
+	doses := make(map[[2]int]float64, len(plots))
+	for _, plot := range plots {
+		doses[plot] = float64(seeds)
+	}
+
+	return planSpray(space, path, doses, float64(capacity))
+}
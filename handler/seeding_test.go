@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/naufalfmm/plantation-drone-api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmptyPlots(t *testing.T) {
+	t.Run("List the empty plots in the serpentine order", func(t *testing.T) {
+		space := newAirspace([][]int{{10, 0, 0}, {0, 20, 0}}, nil, nil)
+
+		assert.Equal(t, [][2]int{{2, 1}, {3, 1}, {3, 2}, {1, 2}}, space.emptyPlots())
+	})
+
+	t.Run("Leave out the obstacles", func(t *testing.T) {
+		space := newAirspace([][]int{{10, 0, 0}, {0, 20, 0}}, []repository.EstateObstacle{
+			{X: 2, Y: 1, Height: 5},
+			{X: 3, Y: 2, NoFly: true},
+		}, nil)
+
+		assert.Equal(t, [][2]int{{3, 1}, {1, 2}}, space.emptyPlots())
+	})
+
+	t.Run("Return no plots when every plot has a tree", func(t *testing.T) {
+		space := newAirspace([][]int{{10, 20}}, nil, nil)
+
+		assert.Empty(t, space.emptyPlots())
+	})
+}
+
+func TestPlanSeeding(t *testing.T) {
+	t.Run("Refill the seeds at home before the plot it cannot seed", func(t *testing.T) {
+		space := newAirspace([][]int{{10, 0, 0}, {0, 20, 0}}, nil, nil)
+
+		mission, ok := planSeeding(space, buildFlightPath(space.visiting(space.emptyPlots())), 1, 2)
+
+		assert.True(t, ok)
+		assert.Equal(t, sprayMission{
+			refills: []sprayRefill{
+				{at: flightWaypoint{X: 3, Y: 2, Altitude: 1, Distance: 21}, payload: 2, plots: 2},
+			},
+			payload: 4,
+			plots:   4,
+			// The path climbs over the tree at (2, 2) on the way to (1, 2),
+			// going back home from (3, 2) and coming back is 20 + 20 more
+			legs: flightLegSum{horizontal: 80, climb: 22, descent: 22},
+		}, mission)
+	})
+
+	t.Run("Seed the whole estate without a refill", func(t *testing.T) {
+		space := newAirspace([][]int{{10, 0, 0}, {0, 20, 0}}, nil, nil)
+
+		mission, ok := planSeeding(space, buildFlightPath(space.visiting(space.emptyPlots())), 2, 8)
+
+		assert.True(t, ok)
+		assert.Empty(t, mission.refills)
+		assert.Equal(t, 8.0, mission.payload)
+		assert.Equal(t, 4, mission.plots)
+		assert.Equal(t, flightLegSum{horizontal: 40, climb: 21, descent: 21}, mission.legs)
+	})
+
+	t.Run("Fail when a plot needs more seeds than the drone carries", func(t *testing.T) {
+		space := newAirspace([][]int{{10, 0, 0}}, nil, nil)
+
+		_, ok := planSeeding(space, buildFlightPath(space.visiting(space.emptyPlots())), 3, 2)
+
+		assert.False(t, ok)
+	})
+}
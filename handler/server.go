@@ -4,14 +4,17 @@ import "github.com/naufalfmm/plantation-drone-api/repository"
 
 type Server struct {
 	Repository repository.RepositoryInterface
+	Hub        *MissionHub
 }
 
 type NewServerOptions struct {
 	Repository repository.RepositoryInterface
+	Hub        *MissionHub
 }
 
 func NewServer(opts NewServerOptions) *Server {
 	return &Server{
 		Repository: opts.Repository,
+		Hub:        opts.Hub,
 	}
 }
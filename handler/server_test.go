@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/naufalfmm/plantation-drone-api/generated"
 	"github.com/naufalfmm/plantation-drone-api/repository"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,15 +20,72 @@ func TestNewServer(t *testing.T) {
 		defer ctrl.Finish()
 
 		repoMock := repository.NewMockRepositoryInterface(ctrl)
+		hub := NewMissionHub()
 
 		expServer := &Server{
 			Repository: repoMock,
+			Hub:        hub,
 		}
 
 		server := NewServer(NewServerOptions{
 			Repository: repoMock,
+			Hub:        hub,
 		})
 
 		assert.Equal(t, expServer, server)
 	})
 }
+
+func TestRegisterHandlers(t *testing.T) {
+	serve := func(t *testing.T, method, path string, setup func(mockRepo *repository.MockRepositoryInterface)) *httptest.ResponseRecorder {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		setup(mockRepo)
+
+		e := echo.New()
+		generated.RegisterHandlers(e, NewServer(NewServerOptions{
+			Repository: mockRepo,
+			Hub:        NewMissionHub(),
+		}))
+
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		resRecorder := httptest.NewRecorder()
+		e.ServeHTTP(resRecorder, req)
+
+		return resRecorder
+	}
+
+	t.Run("Route the snapshot path to the snapshot endpoints", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			resRecorder := serve(t, method, "/estate/estate-1/drone-plan:snapshot", func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateById(gomock.Any(), repository.GetEstateByIdInput{
+					Id: "estate-1",
+				}).Return(repository.GetEstateByIdOutput{}, sql.ErrNoRows)
+			})
+
+			resp := readJsonResult(t, resRecorder.Result())
+
+			assert.Equal(t, http.StatusNotFound, resRecorder.Code, method)
+			assert.Equal(t, ErrNotFoundBuilder("estate").Error(), resp["message"], method)
+		}
+	})
+
+	t.Run("Return 404 for a path that only starts like the drone plan", func(t *testing.T) {
+		for _, path := range []string{
+			"/estate/estate-1/drone-planXYZ",
+			"/estate/estate-1/drone-plan/snapshot",
+			"/estate/estate-1/drone-plan:snapshotXYZ",
+			"/estate/estate-1/drone-plan-snapshot",
+		} {
+			resRecorder := serve(t, http.MethodGet, path, func(*repository.MockRepositoryInterface) {})
+
+			resp := readJsonResult(t, resRecorder.Result())
+
+			assert.Equal(t, http.StatusNotFound, resRecorder.Code, path)
+			assert.Equal(t, "Not Found", resp["message"], path)
+		}
+	})
+}
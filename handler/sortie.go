@@ -0,0 +1,144 @@
+package handler
+
+// flightLegSum is the meters flown horizontally, climbed and descended, like
+// flightLegs splits a path
+type flightLegSum struct {
+	horizontal int
+	climb      int
+	descent    int
+}
+
+func (l flightLegSum) add(o flightLegSum) flightLegSum {
+	return flightLegSum{
+		horizontal: l.horizontal + o.horizontal,
+		climb:      l.climb + o.climb,
+		descent:    l.descent + o.descent,
+	}
+}
+
+// hop is the leg from the drone altitude above a plot to the altitude above
+// the adjacent one
+func hop(fromAltitude, toAltitude int) flightLegSum {
+	leg := flightLegSum{horizontal: plotDistance}
+	if toAltitude > fromAltitude {
+		leg.climb = toAltitude - fromAltitude
+	} else {
+		leg.descent = fromAltitude - toAltitude
+	}
+
+	return leg
+}
+
+// ferries finds the legs the drone flies from its altitude above home to its
+// altitude above every plot it can reach, and back along the same plots. A
+// plot missing from the maps cannot be reached from home.
+func (s airspace) ferries(homeX, homeY int) (out, back map[[2]int]flightLegSum) {
+	type plot = [2]int
+
+	home := plot{homeX, homeY}
+	out = map[plot]flightLegSum{home: {}}
+	back = map[plot]flightLegSum{home: {}}
+
+	queue := []plot{home}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, next := range []plot{{cur[0] + 1, cur[1]}, {cur[0] - 1, cur[1]}, {cur[0], cur[1] + 1}, {cur[0], cur[1] - 1}} {
+			if _, seen := out[next]; seen || !s.flyable(next[0], next[1]) {
+				continue
+			}
+
+			curAltitude, nextAltitude := s.altitude(cur[0], cur[1]), s.altitude(next[0], next[1])
+			out[next] = out[cur].add(hop(curAltitude, nextAltitude))
+			back[next] = hop(nextAltitude, curAltitude).add(back[cur])
+
+			queue = append(queue, next)
+		}
+	}
+
+	return out, back
+}
+
+// flightSortie is one battery worth of the flight path. The drone takes off
+// from home, ferries to the waypoint it resumes from, flies the path up to
+// the waypoint it stops at, then ferries back home to land and swap the
+// battery.
+type flightSortie struct {
+	resume flightWaypoint
+	stop   flightWaypoint
+	cost   flightCost
+}
+
+// planSorties splits the flight path over the estate into sorties that each
+// stay within the limit, ferries included. Home is the home of the airspace,
+// or the first plot of the path when it has none. It returns false when a
+// sortie cannot cover any new waypoint within the limit.
+func planSorties(space airspace, limit flightLimit, profile droneProfile) (sorties []flightSortie, ok bool) {
+	home := space.home
+	space.home = nil
+
+	path := buildFlightPath(space)
+	if len(path) == 0 {
+		return nil, true
+	}
+
+	if home == nil {
+		home = &[2]int{path[0].X, path[0].Y}
+	}
+
+	space.home = home
+	out, back := space.ferries(home[0], home[1])
+
+	homeAltitude := space.altitude(home[0], home[1])
+	takeoff := flightLegSum{climb: homeAltitude - space.groundAt(home[0], home[1])}
+	landing := flightLegSum{descent: takeoff.climb}
+
+	sortieCost := func(legs flightLegSum, stop flightWaypoint) flightCost {
+		legs = legs.add(back[[2]int{stop.X, stop.Y}]).add(landing)
+
+		return profile.cost(legs.horizontal, legs.climb, legs.descent)
+	}
+
+	resume := 0
+	for {
+		resumeOut, reachable := out[[2]int{path[resume].X, path[resume].Y}]
+		if !reachable {
+			return nil, false
+		}
+
+		// The first sortie covers the waypoint it resumes from, the next ones
+		// resume from the waypoint the previous sortie already covered
+		legs := takeoff.add(resumeOut)
+		stop, cost := resume, sortieCost(legs, path[resume])
+		if len(sorties) == 0 && !limit.allows(cost) {
+			return nil, false
+		}
+
+		for stop+1 < len(path) {
+			nextLegs := legs.add(hop(path[stop].Altitude, path[stop+1].Altitude))
+			nextCost := sortieCost(nextLegs, path[stop+1])
+			if !limit.allows(nextCost) {
+				break
+			}
+
+			legs, stop, cost = nextLegs, stop+1, nextCost
+		}
+
+		if len(sorties) > 0 && stop == resume {
+			return nil, false
+		}
+
+		sorties = append(sorties, flightSortie{
+			resume: path[resume],
+			stop:   path[stop],
+			cost:   cost,
+		})
+
+		if stop == len(path)-1 {
+			return sorties, true
+		}
+
+		resume = stop
+	}
+}
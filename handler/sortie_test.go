@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/naufalfmm/plantation-drone-api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanSorties(t *testing.T) {
+	t.Run("Swap the battery at home halfway", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 0, 0, 0, 0}}, nil, nil).withHome(3, 1)
+
+		sorties, ok := planSorties(space, flightLimit{maxDistance: 50}, defaultDroneProfile)
+
+		assert.True(t, ok)
+		assert.Equal(t, []flightSortie{
+			{
+				resume: flightWaypoint{X: 1, Y: 1, Altitude: 1, Distance: 1},
+				stop:   flightWaypoint{X: 3, Y: 1, Altitude: 1, Distance: 21},
+				// 20 ferrying to (1, 1) and 20 flying back over home
+				cost: defaultDroneProfile.cost(40, 1, 1),
+			},
+			{
+				resume: flightWaypoint{X: 3, Y: 1, Altitude: 1, Distance: 21},
+				stop:   flightWaypoint{X: 5, Y: 1, Altitude: 1, Distance: 41},
+				// 20 flying to (5, 1) and 20 ferrying back home
+				cost: defaultDroneProfile.cost(40, 1, 1),
+			},
+		}, sorties)
+	})
+
+	t.Run("Go back to the first plot without home", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 10}}, nil, nil)
+
+		sorties, ok := planSorties(space, flightLimit{maxDistance: 100}, defaultDroneProfile)
+
+		assert.True(t, ok)
+		assert.Len(t, sorties, 1)
+		assert.Equal(t, defaultDroneProfile.cost(20, 11, 11), sorties[0].cost)
+	})
+
+	t.Run("Fail when the first plot is out of range", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 0, 0, 0, 0}}, nil, nil).withHome(3, 1)
+
+		sorties, ok := planSorties(space, flightLimit{maxDistance: 30}, defaultDroneProfile)
+
+		assert.False(t, ok)
+		assert.Nil(t, sorties)
+	})
+
+	t.Run("Fail when the plan cannot go further", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 0, 0, 0, 0}}, nil, nil).withHome(1, 1)
+
+		sorties, ok := planSorties(space, flightLimit{maxDistance: 50}, defaultDroneProfile)
+
+		assert.False(t, ok)
+		assert.Nil(t, sorties)
+	})
+
+	t.Run("Fail when home is walled off by no-fly plots", func(t *testing.T) {
+		space := newAirspace([][]int{{0, 0, 0}}, []repository.EstateObstacle{{X: 2, Y: 1, NoFly: true}}, nil).withHome(3, 1)
+
+		sorties, ok := planSorties(space, flightLimit{maxDistance: 100}, defaultDroneProfile)
+
+		assert.False(t, ok)
+		assert.Nil(t, sorties)
+	})
+}
@@ -0,0 +1,19 @@
+package handler
+
+// defaultMaxTreeHeight bounds the height of a tree planted without a species
+const defaultMaxTreeHeight = 30
+
+const (
+	growthProfileSlow     = "slow"
+	growthProfileModerate = "moderate"
+	growthProfileFast     = "fast"
+)
+
+func isGrowthProfile(profile string) bool {
+	switch profile {
+	case growthProfileSlow, growthProfileModerate, growthProfileFast:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsGrowthProfile(t *testing.T) {
+	t.Run("Accept the known growth profiles only", func(t *testing.T) {
+		assert.True(t, isGrowthProfile(growthProfileSlow))
+		assert.True(t, isGrowthProfile(growthProfileModerate))
+		assert.True(t, isGrowthProfile(growthProfileFast))
+		assert.False(t, isGrowthProfile("rapid"))
+		assert.False(t, isGrowthProfile(""))
+	})
+}
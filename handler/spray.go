@@ -0,0 +1,98 @@
+package handler
+
+import "sort"
+
+// sprayTolerance is how many liters a dose may overflow the tank by because
+// of floating point rounding
+const sprayTolerance = 1e-9
+
+// sprayRefill is where the drone leaves the path to refill its tank at home,
+// before dropping the payload on the plot below, and how much it dropped on
+// how many plots since it last filled the tank
+type sprayRefill struct {
+	at      flightWaypoint
+	payload float64
+	plots   int
+}
+
+// sprayMission is the flight path with the refills on the way. The payload is
+// the chemical for a spray mission and the seeds for a seeding one.
+type sprayMission struct {
+	refills []sprayRefill
+	payload float64
+	plots   int
+	legs    flightLegSum
+
+	// unreachable are the plots with a dose the drone cannot fly to from
+	// home, ordered by row then column, which are left unsprayed
+	unreachable [][2]int
+}
+
+// planSpray follows the flight path over the airspace and sprays the dose of every plot the
+// drone stops above, once even when it stops there again. Once the tank
+// cannot hold the next dose, the drone ferries home to refill and back to the
+// plot. Home is the home of the airspace, or the first plot of the path when
+// it has none. The plots the drone cannot reach from home are not sprayed,
+// since it could not ferry back to refill there. It returns false when a dose
+// is more than the tank holds.
+func planSpray(space airspace, path []flightWaypoint, doses map[[2]int]float64, tank float64) (mission sprayMission, ok bool) {
+	var out, back map[[2]int]flightLegSum
+	if len(path) > 0 {
+		home := space.home
+		if home == nil {
+			home = &[2]int{path[0].X, path[0].Y}
+		}
+
+		space.home = home
+		out, back = space.ferries(home[0], home[1])
+	}
+
+	for plot, dose := range doses {
+		if _, ok := out[plot]; !ok && dose > 0 {
+			mission.unreachable = append(mission.unreachable, plot)
+		}
+	}
+
+	sort.Slice(mission.unreachable, func(i, j int) bool {
+		a, b := mission.unreachable[i], mission.unreachable[j]
+		return a[1] < b[1] || a[1] == b[1] && a[0] < b[0]
+	})
+
+	if len(path) == 0 {
+		return mission, true
+	}
+
+	home := space.home
+	refill := flightLegSum{climb: space.altitude(home[0], home[1]) - space.groundAt(home[0], home[1])}
+	refill.descent = refill.climb
+
+	horizontal, climb, descent := flightLegs(path)
+	mission.legs = flightLegSum{horizontal: horizontal, climb: climb, descent: descent}
+
+	load, plots, sprayed := 0.0, 0, map[[2]int]bool{}
+	for _, wp := range path {
+		plot := [2]int{wp.X, wp.Y}
+		dose := doses[plot]
+		if _, ok := out[plot]; !ok || wp.Transit || dose <= 0 || sprayed[plot] {
+			continue
+		}
+
+		if dose > tank+sprayTolerance {
+			return sprayMission{}, false
+		}
+
+		if load+dose > tank+sprayTolerance {
+			mission.legs = mission.legs.add(back[plot]).add(refill).add(out[plot])
+			mission.refills = append(mission.refills, sprayRefill{at: wp, payload: load, plots: plots})
+			load, plots = 0, 0
+		}
+
+		sprayed[plot] = true
+		load += dose
+		plots++
+		mission.payload += dose
+		mission.plots++
+	}
+
+	return mission, true
+}
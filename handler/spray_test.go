@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/naufalfmm/plantation-drone-api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanSpray(t *testing.T) {
+	doses := map[[2]int]float64{{1, 1}: 5, {3, 1}: 10}
+
+	t.Run("Refill the tank at home before the tree it cannot hold", func(t *testing.T) {
+		space := newAirspace([][]int{{10, 0, 20}}, nil, nil)
+
+		mission, ok := planSpray(space, buildFlightPath(space), doses, 12)
+
+		assert.True(t, ok)
+		assert.Equal(t, sprayMission{
+			refills: []sprayRefill{
+				{at: flightWaypoint{X: 3, Y: 1, Altitude: 21, Distance: 61}, payload: 5, plots: 1},
+			},
+			payload: 15,
+			plots:   2,
+			// The path is 20 + 31 + 31, going back home from (3, 1) is
+			// 20 + 10 + 20, refilling 11 + 11 and coming back 20 + 20 + 10
+			legs: flightLegSum{horizontal: 60, climb: 72, descent: 72},
+		}, mission)
+	})
+
+	t.Run("Spray the whole estate on one tank", func(t *testing.T) {
+		space := newAirspace([][]int{{10, 0, 20}}, nil, nil)
+
+		mission, ok := planSpray(space, buildFlightPath(space), doses, 15)
+
+		assert.True(t, ok)
+		assert.Empty(t, mission.refills)
+		assert.Equal(t, 15.0, mission.payload)
+		assert.Equal(t, flightLegSum{horizontal: 20, climb: 31, descent: 31}, mission.legs)
+	})
+
+	t.Run("Spray the tree under the home once", func(t *testing.T) {
+		space := newAirspace([][]int{{10, 0, 20}}, nil, nil).withHome(1, 1)
+
+		mission, ok := planSpray(space, buildFlightPath(space), doses, 15)
+
+		assert.True(t, ok)
+		assert.Empty(t, mission.refills)
+		assert.Equal(t, 15.0, mission.payload)
+	})
+
+	t.Run("Leave out the tree the drone cannot reach from home", func(t *testing.T) {
+		space := newAirspace([][]int{{10, 0, 20}}, []repository.EstateObstacle{{X: 2, Y: 1, NoFly: true}}, nil)
+
+		mission, ok := planSpray(space, buildFlightPath(space), doses, 12)
+
+		assert.True(t, ok)
+		assert.Equal(t, sprayMission{
+			payload:     5,
+			plots:       1,
+			legs:        flightLegSum{climb: 11, descent: 11},
+			unreachable: [][2]int{{3, 1}},
+		}, mission)
+	})
+
+	t.Run("Fail when a dose is more than the tank holds", func(t *testing.T) {
+		space := newAirspace([][]int{{10, 0, 20}}, nil, nil)
+
+		mission, ok := planSpray(space, buildFlightPath(space), doses, 8)
+
+		assert.False(t, ok)
+		assert.Equal(t, sprayMission{}, mission)
+	})
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"math"
+
+	"github.com/naufalfmm/plantation-drone-api/repository"
+)
+
+// deviationReport compares the track the drone reported against the
+// waypoints of the plan it flew
+type deviationReport struct {
+	points int
+
+	// maxAltitudeDeviation is the furthest the drone flew above or below the
+	// planned altitude of a plot, at maxDeviationAt. Points over a plot the
+	// plan does not fly over are only counted in offPlan.
+	maxAltitudeDeviation float64
+	maxDeviationAt       *[2]int
+	offPlan              int
+
+	// skipped are the plots the plan stops above that the drone never
+	// reported, in the order of the plan
+	skipped [][2]int
+
+	// plannedDistance and actualDistance are flown from the first waypoint
+	// or point to the last one, leaving out the takeoff and the landing
+	plannedDistance int
+	actualDistance  float64
+
+	batteryUsed float64
+}
+
+func buildDeviationReport(plan []repository.PlanWaypoint, track []repository.TelemetryPoint) deviationReport {
+	report := deviationReport{
+		points:  len(track),
+		skipped: [][2]int{},
+	}
+
+	planned := make(map[[2]int]int, len(plan))
+	for i, wp := range plan {
+		plot := [2]int{wp.X, wp.Y}
+		if _, ok := planned[plot]; !ok {
+			planned[plot] = wp.Altitude
+		}
+
+		if i > 0 {
+			prev := plan[i-1]
+			report.plannedDistance += plotDistance*(abs(wp.X-prev.X)+abs(wp.Y-prev.Y)) + abs(wp.Altitude-prev.Altitude)
+		}
+	}
+
+	reported := make(map[[2]int]bool, len(track))
+	for i, point := range track {
+		plot := [2]int{point.X, point.Y}
+		reported[plot] = true
+
+		if altitude, ok := planned[plot]; ok {
+			if deviation := math.Abs(point.Altitude - float64(altitude)); report.maxDeviationAt == nil || deviation > report.maxAltitudeDeviation {
+				report.maxAltitudeDeviation, report.maxDeviationAt = deviation, &plot
+			}
+		} else {
+			report.offPlan++
+		}
+
+		if i > 0 {
+			prev := track[i-1]
+			report.actualDistance += float64(plotDistance*(abs(point.X-prev.X)+abs(point.Y-prev.Y))) + math.Abs(point.Altitude-prev.Altitude)
+		}
+	}
+
+	seen := make(map[[2]int]bool, len(plan))
+	for _, wp := range plan {
+		plot := [2]int{wp.X, wp.Y}
+		if wp.Transit || reported[plot] || seen[plot] {
+			continue
+		}
+
+		seen[plot] = true
+		report.skipped = append(report.skipped, plot)
+	}
+
+	if len(track) > 0 {
+		report.batteryUsed = track[0].Battery - track[len(track)-1].Battery
+	}
+
+	return report
+}
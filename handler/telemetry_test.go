@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/naufalfmm/plantation-drone-api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildDeviationReport(t *testing.T) {
+	plan := []repository.PlanWaypoint{
+		{X: 1, Y: 1, Altitude: 11},
+		{X: 2, Y: 1, Altitude: 21, Transit: true},
+		{X: 3, Y: 1, Altitude: 6},
+		{X: 3, Y: 2, Altitude: 6},
+	}
+
+	t.Run("Compare the track against the plan", func(t *testing.T) {
+		track := []repository.TelemetryPoint{
+			{X: 1, Y: 1, Altitude: 12, Battery: 100},
+			{X: 2, Y: 1, Altitude: 20, Battery: 99},
+			{X: 3, Y: 1, Altitude: 9, Battery: 98},
+			{X: 2, Y: 2, Altitude: 7, Battery: 97},
+		}
+
+		assert.Equal(t, deviationReport{
+			points:               4,
+			maxAltitudeDeviation: 3,
+			maxDeviationAt:       &[2]int{3, 1},
+			offPlan:              1,
+			skipped:              [][2]int{{3, 2}},
+			// 20 + 25 + 10 planned, and 18 + 21 + 22 flown with (2, 2) two
+			// plots away from (3, 1)
+			plannedDistance: 55,
+			actualDistance:  61,
+			batteryUsed:     3,
+		}, buildDeviationReport(plan, track))
+	})
+
+	t.Run("Skip every stop without a track", func(t *testing.T) {
+		report := buildDeviationReport(plan, nil)
+
+		assert.Equal(t, 0, report.points)
+		assert.Nil(t, report.maxDeviationAt)
+		assert.Equal(t, [][2]int{{1, 1}, {3, 1}, {3, 2}}, report.skipped)
+		assert.Equal(t, 0., report.actualDistance)
+	})
+}
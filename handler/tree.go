@@ -0,0 +1,35 @@
+package handler
+
+const (
+	treeStatusPlanted  = "planted"
+	treeStatusMature   = "mature"
+	treeStatusDiseased = "diseased"
+	treeStatusFelled   = "felled"
+)
+
+// treeTransitions lists the statuses every status may move to. A diseased
+// tree may recover, but a felled tree stays felled.
+var treeTransitions = map[string][]string{
+	treeStatusPlanted:  {treeStatusMature, treeStatusDiseased, treeStatusFelled},
+	treeStatusMature:   {treeStatusDiseased, treeStatusFelled},
+	treeStatusDiseased: {treeStatusPlanted, treeStatusMature, treeStatusFelled},
+}
+
+func isTreeStatus(status string) bool {
+	switch status {
+	case treeStatusPlanted, treeStatusMature, treeStatusDiseased, treeStatusFelled:
+		return true
+	}
+
+	return false
+}
+
+func canMoveTree(from, to string) bool {
+	for _, next := range treeTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTreeStatus(t *testing.T) {
+	t.Run("Accept the lifecycle statuses only", func(t *testing.T) {
+		assert.True(t, isTreeStatus(treeStatusPlanted))
+		assert.True(t, isTreeStatus(treeStatusMature))
+		assert.True(t, isTreeStatus(treeStatusDiseased))
+		assert.True(t, isTreeStatus(treeStatusFelled))
+		assert.False(t, isTreeStatus("dead"))
+	})
+}
+
+func TestCanMoveTree(t *testing.T) {
+	t.Run("Grow, sicken or fell a planted tree", func(t *testing.T) {
+		assert.True(t, canMoveTree(treeStatusPlanted, treeStatusMature))
+		assert.True(t, canMoveTree(treeStatusPlanted, treeStatusDiseased))
+		assert.True(t, canMoveTree(treeStatusPlanted, treeStatusFelled))
+		assert.False(t, canMoveTree(treeStatusPlanted, treeStatusPlanted))
+	})
+
+	t.Run("Keep a mature tree mature unless it sickens or is felled", func(t *testing.T) {
+		assert.True(t, canMoveTree(treeStatusMature, treeStatusDiseased))
+		assert.True(t, canMoveTree(treeStatusMature, treeStatusFelled))
+		assert.False(t, canMoveTree(treeStatusMature, treeStatusPlanted))
+	})
+
+	t.Run("Recover a diseased tree", func(t *testing.T) {
+		assert.True(t, canMoveTree(treeStatusDiseased, treeStatusPlanted))
+		assert.True(t, canMoveTree(treeStatusDiseased, treeStatusMature))
+		assert.True(t, canMoveTree(treeStatusDiseased, treeStatusFelled))
+	})
+
+	t.Run("Keep a felled tree felled", func(t *testing.T) {
+		assert.False(t, canMoveTree(treeStatusFelled, treeStatusPlanted))
+		assert.False(t, canMoveTree(treeStatusFelled, treeStatusMature))
+		assert.False(t, canMoveTree(treeStatusFelled, treeStatusDiseased))
+	})
+}
@@ -3,10 +3,21 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 func (r *Repository) CreateEstate(ctx context.Context, input CreateEstateInput) (err error) {
-	err = r.Db.QueryRowContext(ctx, `INSERT INTO estates (id, width, length, drone_distance, created_at, updated_at) VALUES ($1, $2, $3, $4, NOW(), NOW())`, input.Id, input.Width, input.Length, ((input.Length-1)*10*input.Width + (input.Width-1)*10 + 2)).Err()
+	xs, ys, elevations := splitElevations(input.Elevations)
+
+	err = r.Db.QueryRowContext(ctx, `WITH estate AS (
+			INSERT INTO estates (id, width, length, drone_distance, ceiling, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING id
+		)
+		INSERT INTO estate_elevations (estate_id, x, y, elevation, created_at, updated_at)
+		SELECT estate.id, e.x, e.y, e.elevation, NOW(), NOW() FROM estate, unnest($6::BIGINT[], $7::BIGINT[], $8::BIGINT[]) AS e(x, y, elevation)
+	`, input.Id, input.Width, input.Length, ((input.Length-1)*10*input.Width + (input.Width-1)*10 + 2 + input.ElevationDistance), input.Ceiling, xs, ys, elevations).Err()
 	if err != nil {
 		return
 	}
@@ -15,7 +26,7 @@ func (r *Repository) CreateEstate(ctx context.Context, input CreateEstateInput)
 }
 
 func (r *Repository) GetEstateById(ctx context.Context, input GetEstateByIdInput) (output GetEstateByIdOutput, err error) {
-	err = r.Db.QueryRowContext(ctx, `SELECT id, width, length, count, max, min, median, drone_distance FROM estates WHERE id = $1`, input.Id).Scan(&output.Id, &output.Width, &output.Length, &output.Count, &output.Max, &output.Min, &output.Median, &output.DroneDistance)
+	err = r.Db.QueryRowContext(ctx, `SELECT id, width, length, count, max, min, median, drone_distance, ceiling, home_x, home_y, tree_version FROM estates WHERE id = $1`, input.Id).Scan(&output.Id, &output.Width, &output.Length, &output.Count, &output.Max, &output.Min, &output.Median, &output.DroneDistance, &output.Ceiling, &output.HomeX, &output.HomeY, &output.TreeVersion)
 	if err != nil {
 		return
 	}
@@ -24,7 +35,7 @@ func (r *Repository) GetEstateById(ctx context.Context, input GetEstateByIdInput
 }
 
 func (r *Repository) CountCoordinateTree(ctx context.Context, input CountCoordinateTreeInput) (output CountCoordinateTreeOutput, err error) {
-	err = r.Db.QueryRowContext(ctx, `SELECT COUNT(id) FROM estate_trees WHERE x = $1 AND y = $2`, input.X, input.Y).Scan(&output.Count)
+	err = r.Db.QueryRowContext(ctx, `SELECT COUNT(id) FROM estate_trees WHERE estate_id = $1 AND x = $2 AND y = $3 AND status <> 'felled'`, input.EstateId, input.X, input.Y).Scan(&output.Count)
 	if err != nil {
 		return
 	}
@@ -33,25 +44,35 @@ func (r *Repository) CountCoordinateTree(ctx context.Context, input CountCoordin
 }
 
 func (r *Repository) GetPrevNextTree(ctx context.Context, input GetPrevNextTreeInput) (output GetPrevNextTreeOutput, err error) {
-	stmts, err := r.Db.QueryContext(ctx, `SELECT x, y, height FROM estate_trees WHERE (x = $1 AND y = $2) OR (x = $3 AND y = $4) LIMIT 2`, input.PrevX, input.PrevY, input.NextX, input.NextY)
+	stmts, err := r.Db.QueryContext(ctx, `SELECT p.x, p.y, COALESCE(t.height, 0), COALESCE(e.elevation, 0)
+		FROM (VALUES ($2::BIGINT, $3::BIGINT), ($4::BIGINT, $5::BIGINT), ($6::BIGINT, $7::BIGINT)) AS p(x, y)
+		LEFT JOIN estate_trees t ON t.estate_id = $1 AND t.x = p.x AND t.y = p.y AND t.status <> 'felled'
+		LEFT JOIN estate_elevations e ON e.estate_id = $1 AND e.x = p.x AND e.y = p.y
+	`, input.EstateId, input.PrevX, input.PrevY, input.X, input.Y, input.NextX, input.NextY)
 	if err != nil {
 		return
 	}
 
 	for stmts.Next() {
-		x, y, height := 0, 0, 0
+		x, y, height, elevation := 0, 0, 0, 0
 
-		err = stmts.Scan(&x, &y, &height)
+		err = stmts.Scan(&x, &y, &height, &elevation)
 		if err != nil {
 			return
 		}
 
 		if x == input.PrevX && y == input.PrevY {
 			output.PrevTreeHeight = height
+			output.PrevElevation = elevation
+		}
+
+		if x == input.X && y == input.Y {
+			output.Elevation = elevation
 		}
 
 		if x == input.NextX && y == input.NextY {
 			output.NextTreeHeight = height
+			output.NextElevation = elevation
 		}
 	}
 
@@ -70,7 +91,8 @@ func (r *Repository) CreateTree(ctx context.Context, input CreateTreeInput) (err
 			max = CASE WHEN max < $1 THEN $1 ELSE max END,
 			min = CASE WHEN (min = 0 OR min > $1) THEN $1 ELSE min END,
 			drone_distance = drone_distance + $2,
-			median = 0,
+			median = NULL,
+			tree_version = tree_version + 1,
 			updated_at = NOW()
 		WHERE id = $3
 	`, input.Height, input.DroneDistFactor, input.EstateId)
@@ -79,7 +101,11 @@ func (r *Repository) CreateTree(ctx context.Context, input CreateTreeInput) (err
 	}
 	rows.Close()
 
-	rows, err = tx.QueryContext(ctx, `INSERT INTO estate_trees (id, estate_id, x, y, height, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW())`, input.Id, input.EstateId, input.X, input.Y, input.Height)
+	rows, err = tx.QueryContext(ctx, `WITH tree AS (
+			INSERT INTO estate_trees (id, estate_id, species_id, x, y, height, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW()) RETURNING id, status
+		)
+		INSERT INTO tree_status_history (tree_id, to_status, changed_at) SELECT id, status, NOW() FROM tree
+	`, input.Id, input.EstateId, input.SpeciesId, input.X, input.Y, input.Height)
 	if err != nil {
 		return
 	}
@@ -94,7 +120,7 @@ func (r *Repository) CreateTree(ctx context.Context, input CreateTreeInput) (err
 }
 
 func (r *Repository) GetHeightEstateTrees(ctx context.Context, input GetHeightEstateTreesInput) (output GetHeightEstateTreesOutput, err error) {
-	rows, err := r.Db.QueryContext(ctx, `SELECT height FROM estate_trees WHERE estate_id = $1`, input.EstateId)
+	rows, err := r.Db.QueryContext(ctx, `SELECT height FROM estate_trees WHERE estate_id = $1 AND status <> 'felled'`, input.EstateId)
 	if err != nil {
 		return
 	}
@@ -113,10 +139,562 @@ func (r *Repository) GetHeightEstateTrees(ctx context.Context, input GetHeightEs
 }
 
 func (r *Repository) StoreMedianEstate(ctx context.Context, input StoreMedianEstateInput) (err error) {
-	err = r.Db.QueryRowContext(ctx, `UPDATE estates SET median = $1 WHERE id = $2`, input.Median, input.EstateId).Err()
+	err = r.Db.QueryRowContext(ctx, `UPDATE estates SET median = $1 WHERE id = $2 AND tree_version = $3`, input.Median, input.EstateId, input.TreeVersion).Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetEstateTrees(ctx context.Context, input GetEstateTreesInput) (output GetEstateTreesOutput, err error) {
+	rows, err := r.Db.QueryContext(ctx, `SELECT id, x, y, height, species_id, status FROM estate_trees WHERE estate_id = $1 AND status <> 'felled' AND x BETWEEN $2 AND $3 AND y BETWEEN $4 AND $5 ORDER BY y, x`, input.EstateId, input.MinX, input.MaxX, input.MinY, input.MaxY)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tree EstateTree
+		err = rows.Scan(&tree.Id, &tree.X, &tree.Y, &tree.Height, &tree.SpeciesId, &tree.Status)
+		if err != nil {
+			return GetEstateTreesOutput{}, err
+		}
+
+		output.Trees = append(output.Trees, tree)
+	}
+
+	return
+}
+
+func (r *Repository) CreateObstacle(ctx context.Context, input CreateObstacleInput) (err error) {
+	err = r.Db.QueryRowContext(ctx, `INSERT INTO estate_obstacles (id, estate_id, x, y, height, no_fly, description, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())`, input.Id, input.EstateId, input.X, input.Y, input.Height, input.NoFly, input.Description).Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetEstateObstacles(ctx context.Context, input GetEstateObstaclesInput) (output GetEstateObstaclesOutput, err error) {
+	rows, err := r.Db.QueryContext(ctx, `SELECT id, x, y, height, no_fly, description FROM estate_obstacles WHERE estate_id = $1 ORDER BY y, x`, input.EstateId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var obstacle EstateObstacle
+		err = rows.Scan(&obstacle.Id, &obstacle.X, &obstacle.Y, &obstacle.Height, &obstacle.NoFly, &obstacle.Description)
+		if err != nil {
+			return GetEstateObstaclesOutput{}, err
+		}
+
+		output.Obstacles = append(output.Obstacles, obstacle)
+	}
+
+	return
+}
+
+func (r *Repository) DeleteObstacle(ctx context.Context, input DeleteObstacleInput) (err error) {
+	var id string
+	err = r.Db.QueryRowContext(ctx, `DELETE FROM estate_obstacles WHERE id = $1 AND estate_id = $2 RETURNING id`, input.Id, input.EstateId).Scan(&id)
 	if err != nil {
 		return
 	}
 
 	return
 }
+
+func (r *Repository) GetEstateElevations(ctx context.Context, input GetEstateElevationsInput) (output GetEstateElevationsOutput, err error) {
+	rows, err := r.Db.QueryContext(ctx, `SELECT x, y, elevation FROM estate_elevations WHERE estate_id = $1 ORDER BY y, x`, input.EstateId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var elevation PlotElevation
+		err = rows.Scan(&elevation.X, &elevation.Y, &elevation.Elevation)
+		if err != nil {
+			return GetEstateElevationsOutput{}, err
+		}
+
+		output.Elevations = append(output.Elevations, elevation)
+	}
+
+	return
+}
+
+func (r *Repository) StoreEstateElevations(ctx context.Context, input StoreEstateElevationsInput) (err error) {
+	tx, err := r.Db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `DELETE FROM estate_elevations WHERE estate_id = $1`, input.EstateId)
+	if err != nil {
+		return
+	}
+	rows.Close()
+
+	xs, ys, elevations := splitElevations(input.Elevations)
+	rows, err = tx.QueryContext(ctx, `INSERT INTO estate_elevations (estate_id, x, y, elevation, created_at, updated_at)
+		SELECT $1, e.x, e.y, e.elevation, NOW(), NOW() FROM unnest($2::BIGINT[], $3::BIGINT[], $4::BIGINT[]) AS e(x, y, elevation)
+	`, input.EstateId, xs, ys, elevations)
+	if err != nil {
+		return
+	}
+	rows.Close()
+
+	rows, err = tx.QueryContext(ctx, `UPDATE estates SET drone_distance = drone_distance + $1, updated_at = NOW() WHERE id = $2`, input.DroneDistFactor, input.EstateId)
+	if err != nil {
+		return
+	}
+	rows.Close()
+
+	err = tx.Commit()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) StoreEstateHome(ctx context.Context, input StoreEstateHomeInput) (err error) {
+	err = r.Db.QueryRowContext(ctx, `UPDATE estates SET home_x = $1, home_y = $2, updated_at = NOW() WHERE id = $3`, input.X, input.Y, input.EstateId).Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) StoreEstateCeiling(ctx context.Context, input StoreEstateCeilingInput) (err error) {
+	err = r.Db.QueryRowContext(ctx, `UPDATE estates SET ceiling = $1, updated_at = NOW() WHERE id = $2`, input.Ceiling, input.EstateId).Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) CreateDrone(ctx context.Context, input CreateDroneInput) (err error) {
+	err = r.Db.QueryRowContext(ctx, `INSERT INTO drones (id, model, max_range, max_altitude, cruise_speed, climb_rate, battery_capacity, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())`, input.Id, input.Model, input.MaxRange, input.MaxAltitude, input.CruiseSpeed, input.ClimbRate, input.BatteryCapacity).Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetDroneById(ctx context.Context, input GetDroneByIdInput) (output GetDroneByIdOutput, err error) {
+	err = r.Db.QueryRowContext(ctx, `SELECT id, model, max_range, max_altitude, cruise_speed, climb_rate, battery_capacity FROM drones WHERE id = $1`, input.Id).Scan(&output.Id, &output.Model, &output.MaxRange, &output.MaxAltitude, &output.CruiseSpeed, &output.ClimbRate, &output.BatteryCapacity)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetDrones(ctx context.Context, input GetDronesInput) (output GetDronesOutput, err error) {
+	rows, err := r.Db.QueryContext(ctx, `SELECT id, model, max_range, max_altitude, cruise_speed, climb_rate, battery_capacity FROM drones ORDER BY created_at, id`)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var drone Drone
+		err = rows.Scan(&drone.Id, &drone.Model, &drone.MaxRange, &drone.MaxAltitude, &drone.CruiseSpeed, &drone.ClimbRate, &drone.BatteryCapacity)
+		if err != nil {
+			return GetDronesOutput{}, err
+		}
+
+		output.Drones = append(output.Drones, drone)
+	}
+
+	return
+}
+
+func (r *Repository) UpdateDrone(ctx context.Context, input UpdateDroneInput) (err error) {
+	var id string
+	err = r.Db.QueryRowContext(ctx, `UPDATE drones SET model = $1, max_range = $2, max_altitude = $3, cruise_speed = $4, climb_rate = $5, battery_capacity = $6, updated_at = NOW() WHERE id = $7 RETURNING id`, input.Model, input.MaxRange, input.MaxAltitude, input.CruiseSpeed, input.ClimbRate, input.BatteryCapacity, input.Id).Scan(&id)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DeleteDrone returns sql.ErrNoRows when the drone is missing or is assigned
+// to a mission that has not ended
+func (r *Repository) DeleteDrone(ctx context.Context, input DeleteDroneInput) (err error) {
+	var id string
+	err = r.Db.QueryRowContext(ctx, `DELETE FROM drones WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM missions WHERE drone_id = $1 AND status IN ('scheduled', 'in_progress')) RETURNING id`, input.Id).Scan(&id)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// CreateSpecies returns sql.ErrNoRows when the catalogue already has a
+// species of the same name
+func (r *Repository) CreateSpecies(ctx context.Context, input CreateSpeciesInput) (err error) {
+	var id string
+	err = r.Db.QueryRowContext(ctx, `INSERT INTO tree_species (id, name, max_height, growth_profile, created_at, updated_at) VALUES ($1, $2, $3, $4, NOW(), NOW()) ON CONFLICT (name) DO NOTHING RETURNING id`, input.Id, input.Name, input.MaxHeight, input.GrowthProfile).Scan(&id)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetSpeciesById(ctx context.Context, input GetSpeciesByIdInput) (output GetSpeciesByIdOutput, err error) {
+	err = r.Db.QueryRowContext(ctx, `SELECT id, name, max_height, growth_profile FROM tree_species WHERE id = $1`, input.Id).Scan(&output.Id, &output.Name, &output.MaxHeight, &output.GrowthProfile)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetAllSpecies(ctx context.Context, input GetAllSpeciesInput) (output GetAllSpeciesOutput, err error) {
+	rows, err := r.Db.QueryContext(ctx, `SELECT id, name, max_height, growth_profile FROM tree_species ORDER BY name`)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var species Species
+		err = rows.Scan(&species.Id, &species.Name, &species.MaxHeight, &species.GrowthProfile)
+		if err != nil {
+			return GetAllSpeciesOutput{}, err
+		}
+
+		output.Species = append(output.Species, species)
+	}
+
+	return
+}
+
+// CreateDronePlanSnapshot stores the snapshot as the next version of the
+// estate drone plan
+func (r *Repository) CreateDronePlanSnapshot(ctx context.Context, input CreateDronePlanSnapshotInput) (output CreateDronePlanSnapshotOutput, err error) {
+	waypoints, err := json.Marshal(input.Waypoints)
+	if err != nil {
+		return
+	}
+
+	err = r.Db.QueryRowContext(ctx, `INSERT INTO drone_plan_snapshots (id, estate_id, version, mode, tree_hash, distance, flight_time, energy, waypoints, created_at)
+		SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6, $7, $8, NOW() FROM drone_plan_snapshots WHERE estate_id = $2
+		RETURNING version, created_at
+	`, input.Id, input.EstateId, input.Mode, input.TreeHash, input.Distance, input.FlightTime, input.Energy, waypoints).Scan(&output.Version, &output.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetDronePlanSnapshot(ctx context.Context, input GetDronePlanSnapshotInput) (output GetDronePlanSnapshotOutput, err error) {
+	var waypoints []byte
+	err = r.Db.QueryRowContext(ctx, `SELECT id, estate_id, version, mode, tree_hash, distance, flight_time, energy, waypoints, created_at FROM drone_plan_snapshots
+		WHERE estate_id = $1 AND ($2::BIGINT = 0 OR version = $2) ORDER BY version DESC LIMIT 1
+	`, input.EstateId, input.Version).Scan(&output.Id, &output.EstateId, &output.Version, &output.Mode, &output.TreeHash, &output.Distance, &output.FlightTime, &output.Energy, &waypoints, &output.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(waypoints, &output.Waypoints)
+	if err != nil {
+		return GetDronePlanSnapshotOutput{}, err
+	}
+
+	return
+}
+
+func (r *Repository) CreateMission(ctx context.Context, input CreateMissionInput) (output CreateMissionOutput, err error) {
+	err = r.Db.QueryRowContext(ctx, `INSERT INTO missions (id, estate_id, snapshot_id, drone_id, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING created_at`, input.Id, input.EstateId, input.SnapshotId, input.DroneId, input.Status).Scan(&output.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetMissionById(ctx context.Context, input GetMissionByIdInput) (output GetMissionByIdOutput, err error) {
+	err = r.Db.QueryRowContext(ctx, `SELECT m.id, m.estate_id, m.snapshot_id, s.version, m.drone_id, m.status, m.abort_reason, m.created_at, m.started_at, m.ended_at
+		FROM missions m JOIN drone_plan_snapshots s ON s.id = m.snapshot_id
+		WHERE m.id = $1
+	`, input.Id).Scan(&output.Id, &output.EstateId, &output.SnapshotId, &output.SnapshotVersion, &output.DroneId, &output.Status, &output.AbortReason, &output.CreatedAt, &output.StartedAt, &output.EndedAt)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetMissions(ctx context.Context, input GetMissionsInput) (output GetMissionsOutput, err error) {
+	rows, err := r.Db.QueryContext(ctx, `SELECT m.id, m.estate_id, m.snapshot_id, s.version, m.drone_id, m.status, m.abort_reason, m.created_at, m.started_at, m.ended_at
+		FROM missions m JOIN drone_plan_snapshots s ON s.id = m.snapshot_id
+		WHERE ($1 = '' OR m.estate_id = $1) AND ($2 = '' OR m.status = $2)
+		ORDER BY m.created_at, m.id
+	`, input.EstateId, input.Status)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var mission Mission
+		err = rows.Scan(&mission.Id, &mission.EstateId, &mission.SnapshotId, &mission.SnapshotVersion, &mission.DroneId, &mission.Status, &mission.AbortReason, &mission.CreatedAt, &mission.StartedAt, &mission.EndedAt)
+		if err != nil {
+			return GetMissionsOutput{}, err
+		}
+
+		output.Missions = append(output.Missions, mission)
+	}
+
+	return
+}
+
+// UpdateMissionDrone only assigns the drone while the mission is scheduled
+func (r *Repository) UpdateMissionDrone(ctx context.Context, input UpdateMissionDroneInput) (err error) {
+	var id string
+	err = r.Db.QueryRowContext(ctx, `UPDATE missions SET drone_id = $1, updated_at = NOW() WHERE id = $2 AND status = 'scheduled' RETURNING id`, input.DroneId, input.Id).Scan(&id)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) UpdateMissionStatus(ctx context.Context, input UpdateMissionStatusInput) (output UpdateMissionStatusOutput, err error) {
+	err = r.Db.QueryRowContext(ctx, `UPDATE missions SET status = $1::VARCHAR, abort_reason = $2,
+			started_at = CASE WHEN $1::VARCHAR = 'in_progress' THEN NOW() ELSE started_at END,
+			ended_at = CASE WHEN $1::VARCHAR IN ('completed', 'aborted') THEN NOW() ELSE ended_at END,
+			updated_at = NOW()
+		WHERE id = $3 AND status = $4 RETURNING started_at, ended_at
+	`, input.To, input.AbortReason, input.Id, input.From).Scan(&output.StartedAt, &output.EndedAt)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// StoreMissionTelemetry inserts the whole batch in one statement. A point
+// already stored at the same time is kept as is, so a batch may be posted
+// again after a dropped connection.
+func (r *Repository) StoreMissionTelemetry(ctx context.Context, input StoreMissionTelemetryInput) (output StoreMissionTelemetryOutput, err error) {
+	recordedAts, xs, ys, altitudes, batteries := splitTelemetry(input.Points)
+
+	err = r.Db.QueryRowContext(ctx, `WITH stored AS (
+			INSERT INTO mission_telemetry (mission_id, recorded_at, x, y, altitude, battery)
+			SELECT $1, p.recorded_at, p.x, p.y, p.altitude, p.battery FROM unnest($2::TIMESTAMPTZ[], $3::BIGINT[], $4::BIGINT[], $5::DOUBLE PRECISION[], $6::DOUBLE PRECISION[]) AS p(recorded_at, x, y, altitude, battery)
+			ON CONFLICT (mission_id, recorded_at) DO NOTHING
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM stored
+	`, input.MissionId, recordedAts, xs, ys, altitudes, batteries).Scan(&output.Stored)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetMissionTelemetry(ctx context.Context, input GetMissionTelemetryInput) (output GetMissionTelemetryOutput, err error) {
+	rows, err := r.Db.QueryContext(ctx, `SELECT recorded_at, x, y, altitude, battery FROM mission_telemetry WHERE mission_id = $1 ORDER BY recorded_at`, input.MissionId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var point TelemetryPoint
+		err = rows.Scan(&point.RecordedAt, &point.X, &point.Y, &point.Altitude, &point.Battery)
+		if err != nil {
+			return GetMissionTelemetryOutput{}, err
+		}
+
+		output.Points = append(output.Points, point)
+	}
+
+	return
+}
+
+func (r *Repository) GetTreeById(ctx context.Context, input GetTreeByIdInput) (output GetTreeByIdOutput, err error) {
+	err = r.Db.QueryRowContext(ctx, `SELECT id, x, y, height, species_id, status FROM estate_trees WHERE id = $1 AND estate_id = $2`, input.Id, input.EstateId).Scan(&output.Id, &output.X, &output.Y, &output.Height, &output.SpeciesId, &output.Status)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// UpdateTreeStatus records the move in the history of the tree. A felled tree
+// leaves the stats and the drone distance of the estate.
+func (r *Repository) UpdateTreeStatus(ctx context.Context, input UpdateTreeStatusInput) (err error) {
+	tx, err := r.Db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `UPDATE estate_trees SET status = $1, updated_at = NOW() WHERE id = $2 AND estate_id = $3 AND status = $4 RETURNING id`, input.To, input.Id, input.EstateId, input.From)
+	if err != nil {
+		return
+	}
+	// The tree moved since it was read
+	if !rows.Next() {
+		rows.Close()
+		return sql.ErrNoRows
+	}
+	rows.Close()
+
+	rows, err = tx.QueryContext(ctx, `INSERT INTO tree_status_history (tree_id, from_status, to_status, reason, changed_at) VALUES ($1, $2, $3, $4, NOW())`, input.Id, input.From, input.To, input.Reason)
+	if err != nil {
+		return
+	}
+	rows.Close()
+
+	if input.To == "felled" {
+		rows, err = tx.QueryContext(ctx, `UPDATE estates
+			SET count = count - 1,
+				max = COALESCE((SELECT MAX(height) FROM estate_trees WHERE estate_id = $1 AND status <> 'felled'), 0),
+				min = COALESCE((SELECT MIN(height) FROM estate_trees WHERE estate_id = $1 AND status <> 'felled'), 0),
+				drone_distance = drone_distance + $2,
+				median = NULL,
+				tree_version = tree_version + 1,
+				updated_at = NOW()
+			WHERE id = $1
+		`, input.EstateId, input.DroneDistFactor)
+		if err != nil {
+			return
+		}
+		rows.Close()
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetTreeStatusHistory lists the oldest change first, starting from the
+// planting of the tree
+func (r *Repository) GetTreeStatusHistory(ctx context.Context, input GetTreeStatusHistoryInput) (output GetTreeStatusHistoryOutput, err error) {
+	rows, err := r.Db.QueryContext(ctx, `SELECT from_status, to_status, reason, changed_at FROM tree_status_history WHERE tree_id = $1 ORDER BY changed_at, id`, input.TreeId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change TreeStatusChange
+		err = rows.Scan(&change.From, &change.To, &change.Reason, &change.ChangedAt)
+		if err != nil {
+			return GetTreeStatusHistoryOutput{}, err
+		}
+
+		output.History = append(output.History, change)
+	}
+
+	return
+}
+
+func (r *Repository) CreateTreeObservation(ctx context.Context, input CreateTreeObservationInput) (err error) {
+	err = r.Db.QueryRowContext(ctx, `INSERT INTO tree_observations (id, tree_id, mission_id, health_score, suspected_disease, pests, fruit_count, notes, observed_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, '{}'), $7, $8, COALESCE($9, NOW()), NOW())
+	`, input.Id, input.TreeId, input.MissionId, input.HealthScore, input.SuspectedDisease, pq.StringArray(input.Pests), input.FruitCount, input.Notes, input.ObservedAt).Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetTreeObservations lists the newest observation first
+func (r *Repository) GetTreeObservations(ctx context.Context, input GetTreeObservationsInput) (output GetTreeObservationsOutput, err error) {
+	rows, err := r.Db.QueryContext(ctx, `SELECT o.id, o.tree_id, t.x, t.y, o.mission_id, o.health_score, o.suspected_disease, o.pests, o.fruit_count, o.notes, o.observed_at, o.created_at
+		FROM tree_observations o JOIN estate_trees t ON t.id = o.tree_id
+		WHERE t.estate_id = $1 AND ($2 = '' OR o.tree_id = $2) AND ($3 = '' OR o.mission_id = $3)
+		ORDER BY o.observed_at DESC, o.created_at DESC
+	`, input.EstateId, input.TreeId, input.MissionId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var observation TreeObservation
+		err = rows.Scan(&observation.Id, &observation.TreeId, &observation.X, &observation.Y, &observation.MissionId, &observation.HealthScore, &observation.SuspectedDisease, (*pq.StringArray)(&observation.Pests), &observation.FruitCount, &observation.Notes, &observation.ObservedAt, &observation.CreatedAt)
+		if err != nil {
+			return GetTreeObservationsOutput{}, err
+		}
+
+		output.Observations = append(output.Observations, observation)
+	}
+
+	return
+}
+
+// GetLatestTreeObservations keeps the newest observation of every observed
+// tree of the estate, ordered by the plot of the tree
+func (r *Repository) GetLatestTreeObservations(ctx context.Context, input GetLatestTreeObservationsInput) (output GetLatestTreeObservationsOutput, err error) {
+	rows, err := r.Db.QueryContext(ctx, `SELECT id, tree_id, x, y, mission_id, health_score, suspected_disease, pests, fruit_count, notes, observed_at, created_at FROM (
+			SELECT DISTINCT ON (o.tree_id) o.id, o.tree_id, t.x, t.y, o.mission_id, o.health_score, o.suspected_disease, o.pests, o.fruit_count, o.notes, o.observed_at, o.created_at
+			FROM tree_observations o JOIN estate_trees t ON t.id = o.tree_id
+			WHERE t.estate_id = $1 AND t.status <> 'felled'
+			ORDER BY o.tree_id, o.observed_at DESC, o.created_at DESC
+		) latest
+		ORDER BY y, x
+	`, input.EstateId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var observation TreeObservation
+		err = rows.Scan(&observation.Id, &observation.TreeId, &observation.X, &observation.Y, &observation.MissionId, &observation.HealthScore, &observation.SuspectedDisease, (*pq.StringArray)(&observation.Pests), &observation.FruitCount, &observation.Notes, &observation.ObservedAt, &observation.CreatedAt)
+		if err != nil {
+			return GetLatestTreeObservationsOutput{}, err
+		}
+
+		output.Observations = append(output.Observations, observation)
+	}
+
+	return
+}
+
+// splitElevations turns the plot elevations into the column arrays unnested by the queries
+func splitElevations(plots []PlotElevation) (xs, ys, elevations pq.Int64Array) {
+	for _, plot := range plots {
+		xs = append(xs, int64(plot.X))
+		ys = append(ys, int64(plot.Y))
+		elevations = append(elevations, int64(plot.Elevation))
+	}
+
+	return
+}
+
+// splitTelemetry turns the telemetry points into the column arrays unnested by the queries
+func splitTelemetry(points []TelemetryPoint) (recordedAts pq.StringArray, xs, ys pq.Int64Array, altitudes, batteries pq.Float64Array) {
+	for _, point := range points {
+		recordedAts = append(recordedAts, point.RecordedAt.Format(time.RFC3339Nano))
+		xs = append(xs, int64(point.X))
+		ys = append(ys, int64(point.Y))
+		altitudes = append(altitudes, point.Altitude)
+		batteries = append(batteries, point.Battery)
+	}
+
+	return
+}
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/naufalfmm/plantation-drone-api/utils/db"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,14 +26,59 @@ func TestCreateEstate(t *testing.T) {
 		}
 
 		input := CreateEstateInput{
-			Id:     "aaaaa-bbbbb-ccccc-ddddd",
-			Width:  5,
-			Length: 6,
+			Id:      "aaaaa-bbbbb-ccccc-ddddd",
+			Width:   5,
+			Length:  6,
+			Ceiling: 120,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `WITH estate AS (
+			INSERT INTO estates (id, width, length, drone_distance, ceiling, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING id
+		)
+		INSERT INTO estate_elevations (estate_id, x, y, elevation, created_at, updated_at)
+		SELECT estate.id, e.x, e.y, e.elevation, NOW(), NOW() FROM estate, unnest($6::BIGINT[], $7::BIGINT[], $8::BIGINT[]) AS e(x, y, elevation)
+	`, input.Id, input.Width, input.Length, 292, input.Ceiling, pq.Int64Array(nil), pq.Int64Array(nil), pq.Int64Array(nil)).Return(mockRow)
+		mockRow.EXPECT().Err().Return(nil)
+
+		err := repo.CreateEstate(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return no error when insert with elevations is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := CreateEstateInput{
+			Id:      "aaaaa-bbbbb-ccccc-ddddd",
+			Width:   5,
+			Length:  6,
+			Ceiling: 120,
+
+			Elevations: []PlotElevation{
+				{X: 2, Y: 1, Elevation: 5},
+				{X: 3, Y: 4, Elevation: 8},
+			},
+			ElevationDistance: 26,
 		}
 
 		ctx := context.Background()
 
-		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO estates (id, width, length, drone_distance, created_at, updated_at) VALUES ($1, $2, $3, $4, NOW(), NOW())`, input.Id, input.Width, input.Length, 292).Return(mockRow)
+		mockDb.EXPECT().QueryRowContext(ctx, `WITH estate AS (
+			INSERT INTO estates (id, width, length, drone_distance, ceiling, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING id
+		)
+		INSERT INTO estate_elevations (estate_id, x, y, elevation, created_at, updated_at)
+		SELECT estate.id, e.x, e.y, e.elevation, NOW(), NOW() FROM estate, unnest($6::BIGINT[], $7::BIGINT[], $8::BIGINT[]) AS e(x, y, elevation)
+	`, input.Id, input.Width, input.Length, 318, input.Ceiling, pq.Int64Array{2, 3}, pq.Int64Array{1, 4}, pq.Int64Array{5, 8}).Return(mockRow)
 		mockRow.EXPECT().Err().Return(nil)
 
 		err := repo.CreateEstate(ctx, input)
@@ -53,14 +100,20 @@ func TestCreateEstate(t *testing.T) {
 		errAny := errors.New("any error")
 
 		input := CreateEstateInput{
-			Id:     "aaaaa-bbbbb-ccccc-ddddd",
-			Width:  5,
-			Length: 6,
+			Id:      "aaaaa-bbbbb-ccccc-ddddd",
+			Width:   5,
+			Length:  6,
+			Ceiling: 120,
 		}
 
 		ctx := context.Background()
 
-		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO estates (id, width, length, drone_distance, created_at, updated_at) VALUES ($1, $2, $3, $4, NOW(), NOW())`, input.Id, input.Width, input.Length, 292).Return(mockRow)
+		mockDb.EXPECT().QueryRowContext(ctx, `WITH estate AS (
+			INSERT INTO estates (id, width, length, drone_distance, ceiling, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING id
+		)
+		INSERT INTO estate_elevations (estate_id, x, y, elevation, created_at, updated_at)
+		SELECT estate.id, e.x, e.y, e.elevation, NOW(), NOW() FROM estate, unnest($6::BIGINT[], $7::BIGINT[], $8::BIGINT[]) AS e(x, y, elevation)
+	`, input.Id, input.Width, input.Length, 292, input.Ceiling, pq.Int64Array(nil), pq.Int64Array(nil), pq.Int64Array(nil)).Return(mockRow)
 		mockRow.EXPECT().Err().Return(errAny)
 
 		err := repo.CreateEstate(ctx, input)
@@ -90,8 +143,8 @@ func TestGetEstateById(t *testing.T) {
 		ctx := context.Background()
 
 		out := GetEstateByIdOutput{}
-		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, width, length, count, max, min, median, drone_distance FROM estates WHERE id = $1`, input.Id).Return(mockRow)
-		mockRow.EXPECT().Scan(&out.Id, &out.Width, &out.Length, &out.Count, &out.Max, &out.Min, &out.Median, &out.DroneDistance).Return(nil)
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, width, length, count, max, min, median, drone_distance, ceiling, home_x, home_y, tree_version FROM estates WHERE id = $1`, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(&out.Id, &out.Width, &out.Length, &out.Count, &out.Max, &out.Min, &out.Median, &out.DroneDistance, &out.Ceiling, &out.HomeX, &out.HomeY, &out.TreeVersion).Return(nil)
 
 		output, err := repo.GetEstateById(ctx, input)
 
@@ -119,8 +172,8 @@ func TestGetEstateById(t *testing.T) {
 		ctx := context.Background()
 
 		out := GetEstateByIdOutput{}
-		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, width, length, count, max, min, median, drone_distance FROM estates WHERE id = $1`, input.Id).Return(mockRow)
-		mockRow.EXPECT().Scan(&out.Id, &out.Width, &out.Length, &out.Count, &out.Max, &out.Min, &out.Median, &out.DroneDistance).Return(errAny)
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, width, length, count, max, min, median, drone_distance, ceiling, home_x, home_y, tree_version FROM estates WHERE id = $1`, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(&out.Id, &out.Width, &out.Length, &out.Count, &out.Max, &out.Min, &out.Median, &out.DroneDistance, &out.Ceiling, &out.HomeX, &out.HomeY, &out.TreeVersion).Return(errAny)
 
 		output, err := repo.GetEstateById(ctx, input)
 
@@ -142,14 +195,15 @@ func TestCountCoordinateTree(t *testing.T) {
 		}
 
 		input := CountCoordinateTreeInput{
-			X: 2,
-			Y: 1,
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			X:        2,
+			Y:        1,
 		}
 
 		ctx := context.Background()
 
 		var count int
-		mockDb.EXPECT().QueryRowContext(ctx, `SELECT COUNT(id) FROM estate_trees WHERE x = $1 AND y = $2`, input.X, input.Y).Return(mockRow)
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT COUNT(id) FROM estate_trees WHERE estate_id = $1 AND x = $2 AND y = $3 AND status <> 'felled'`, input.EstateId, input.X, input.Y).Return(mockRow)
 		mockRow.EXPECT().Scan(&count).Return(nil)
 
 		output, err := repo.CountCoordinateTree(ctx, input)
@@ -158,6 +212,39 @@ func TestCountCoordinateTree(t *testing.T) {
 		assert.Equal(t, CountCoordinateTreeOutput{}, output)
 	})
 
+	t.Run("Count only the trees of the estate when another estate shares the plot", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		planted, empty := db.NewMockRow(ctrl), db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		ctx := context.Background()
+
+		query := `SELECT COUNT(id) FROM estate_trees WHERE estate_id = $1 AND x = $2 AND y = $3 AND status <> 'felled'`
+		mockDb.EXPECT().QueryRowContext(ctx, query, "bbbbb-ccccc-ddddd-eeeee", 2, 1).Return(planted)
+		planted.EXPECT().Scan(gomock.Any()).DoAndReturn(func(args ...interface{}) error {
+			*(args[0].(*int)) = 1
+			return nil
+		})
+		mockDb.EXPECT().QueryRowContext(ctx, query, "ccccc-ddddd-eeeee-fffff", 2, 1).Return(empty)
+		empty.EXPECT().Scan(gomock.Any()).Return(nil)
+
+		output, err := repo.CountCoordinateTree(ctx, CountCoordinateTreeInput{EstateId: "bbbbb-ccccc-ddddd-eeeee", X: 2, Y: 1})
+
+		assert.Nil(t, err)
+		assert.Equal(t, CountCoordinateTreeOutput{Count: 1}, output)
+
+		output, err = repo.CountCoordinateTree(ctx, CountCoordinateTreeInput{EstateId: "ccccc-ddddd-eeeee-fffff", X: 2, Y: 1})
+
+		assert.Nil(t, err)
+		assert.Equal(t, CountCoordinateTreeOutput{Count: 0}, output)
+	})
+
 	t.Run("Return error when scan error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -172,14 +259,15 @@ func TestCountCoordinateTree(t *testing.T) {
 		errAny := errors.New("any error")
 
 		input := CountCoordinateTreeInput{
-			X: 2,
-			Y: 1,
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			X:        2,
+			Y:        1,
 		}
 
 		ctx := context.Background()
 
 		var count int
-		mockDb.EXPECT().QueryRowContext(ctx, `SELECT COUNT(id) FROM estate_trees WHERE x = $1 AND y = $2`, input.X, input.Y).Return(mockRow)
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT COUNT(id) FROM estate_trees WHERE estate_id = $1 AND x = $2 AND y = $3 AND status <> 'felled'`, input.EstateId, input.X, input.Y).Return(mockRow)
 		mockRow.EXPECT().Scan(&count).Return(errAny)
 
 		output, err := repo.CountCoordinateTree(ctx, input)
@@ -202,9 +290,14 @@ func TestGetPrevNextTree(t *testing.T) {
 		}
 
 		input := GetPrevNextTreeInput{
+			EstateId: "aaaaa-bbbbb-ccccc-ddddd",
+
 			PrevX: 2,
 			PrevY: 1,
 
+			X: 3,
+			Y: 1,
+
 			NextX: 4,
 			NextY: 1,
 		}
@@ -212,25 +305,44 @@ func TestGetPrevNextTree(t *testing.T) {
 		expOutput := GetPrevNextTreeOutput{
 			PrevTreeHeight: 10,
 			NextTreeHeight: 15,
+
+			PrevElevation: 2,
+			Elevation:     4,
+			NextElevation: 6,
 		}
 
 		ctx := context.Background()
 
-		var x, y, height int
-		mockDb.EXPECT().QueryContext(ctx, `SELECT x, y, height FROM estate_trees WHERE (x = $1 AND y = $2) OR (x = $3 AND y = $4) LIMIT 2`, input.PrevX, input.PrevY, input.NextX, input.NextY).Return(mockRows, nil)
+		var x, y, height, elevation int
+		mockDb.EXPECT().QueryContext(ctx, `SELECT p.x, p.y, COALESCE(t.height, 0), COALESCE(e.elevation, 0)
+		FROM (VALUES ($2::BIGINT, $3::BIGINT), ($4::BIGINT, $5::BIGINT), ($6::BIGINT, $7::BIGINT)) AS p(x, y)
+		LEFT JOIN estate_trees t ON t.estate_id = $1 AND t.x = p.x AND t.y = p.y AND t.status <> 'felled'
+		LEFT JOIN estate_elevations e ON e.estate_id = $1 AND e.x = p.x AND e.y = p.y
+	`, input.EstateId, input.PrevX, input.PrevY, input.X, input.Y, input.NextX, input.NextY).Return(mockRows, nil)
 		mockRows.EXPECT().Next().Return(true)
-		mockRows.EXPECT().Scan(&x, &y, &height).DoAndReturn(func(args ...interface{}) interface{} {
+		mockRows.EXPECT().Scan(&x, &y, &height, &elevation).DoAndReturn(func(args ...interface{}) interface{} {
 			*(args[0].(*int)) = input.PrevX
 			*(args[1].(*int)) = input.PrevY
 			*(args[2].(*int)) = expOutput.PrevTreeHeight
+			*(args[3].(*int)) = expOutput.PrevElevation
+
+			return nil
+		})
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&x, &y, &height, &elevation).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*int)) = input.X
+			*(args[1].(*int)) = input.Y
+			*(args[2].(*int)) = 0
+			*(args[3].(*int)) = expOutput.Elevation
 
 			return nil
 		})
 		mockRows.EXPECT().Next().Return(true)
-		mockRows.EXPECT().Scan(&x, &y, &height).DoAndReturn(func(args ...interface{}) interface{} {
+		mockRows.EXPECT().Scan(&x, &y, &height, &elevation).DoAndReturn(func(args ...interface{}) interface{} {
 			*(args[0].(*int)) = input.NextX
 			*(args[1].(*int)) = input.NextY
 			*(args[2].(*int)) = expOutput.NextTreeHeight
+			*(args[3].(*int)) = expOutput.NextElevation
 
 			return nil
 		})
@@ -256,9 +368,14 @@ func TestGetPrevNextTree(t *testing.T) {
 		errAny := errors.New("any error")
 
 		input := GetPrevNextTreeInput{
+			EstateId: "aaaaa-bbbbb-ccccc-ddddd",
+
 			PrevX: 2,
 			PrevY: 1,
 
+			X: 3,
+			Y: 1,
+
 			NextX: 4,
 			NextY: 1,
 		}
@@ -267,10 +384,14 @@ func TestGetPrevNextTree(t *testing.T) {
 
 		ctx := context.Background()
 
-		var x, y, height int
-		mockDb.EXPECT().QueryContext(ctx, `SELECT x, y, height FROM estate_trees WHERE (x = $1 AND y = $2) OR (x = $3 AND y = $4) LIMIT 2`, input.PrevX, input.PrevY, input.NextX, input.NextY).Return(mockRows, nil)
+		var x, y, height, elevation int
+		mockDb.EXPECT().QueryContext(ctx, `SELECT p.x, p.y, COALESCE(t.height, 0), COALESCE(e.elevation, 0)
+		FROM (VALUES ($2::BIGINT, $3::BIGINT), ($4::BIGINT, $5::BIGINT), ($6::BIGINT, $7::BIGINT)) AS p(x, y)
+		LEFT JOIN estate_trees t ON t.estate_id = $1 AND t.x = p.x AND t.y = p.y AND t.status <> 'felled'
+		LEFT JOIN estate_elevations e ON e.estate_id = $1 AND e.x = p.x AND e.y = p.y
+	`, input.EstateId, input.PrevX, input.PrevY, input.X, input.Y, input.NextX, input.NextY).Return(mockRows, nil)
 		mockRows.EXPECT().Next().Return(true)
-		mockRows.EXPECT().Scan(&x, &y, &height).Return(errAny)
+		mockRows.EXPECT().Scan(&x, &y, &height, &elevation).Return(errAny)
 
 		output, err := repo.GetPrevNextTree(ctx, input)
 
@@ -292,9 +413,14 @@ func TestGetPrevNextTree(t *testing.T) {
 		errAny := errors.New("any error")
 
 		input := GetPrevNextTreeInput{
+			EstateId: "aaaaa-bbbbb-ccccc-ddddd",
+
 			PrevX: 2,
 			PrevY: 1,
 
+			X: 3,
+			Y: 1,
+
 			NextX: 4,
 			NextY: 1,
 		}
@@ -303,7 +429,11 @@ func TestGetPrevNextTree(t *testing.T) {
 
 		ctx := context.Background()
 
-		mockDb.EXPECT().QueryContext(ctx, `SELECT x, y, height FROM estate_trees WHERE (x = $1 AND y = $2) OR (x = $3 AND y = $4) LIMIT 2`, input.PrevX, input.PrevY, input.NextX, input.NextY).Return(mockRows, errAny)
+		mockDb.EXPECT().QueryContext(ctx, `SELECT p.x, p.y, COALESCE(t.height, 0), COALESCE(e.elevation, 0)
+		FROM (VALUES ($2::BIGINT, $3::BIGINT), ($4::BIGINT, $5::BIGINT), ($6::BIGINT, $7::BIGINT)) AS p(x, y)
+		LEFT JOIN estate_trees t ON t.estate_id = $1 AND t.x = p.x AND t.y = p.y AND t.status <> 'felled'
+		LEFT JOIN estate_elevations e ON e.estate_id = $1 AND e.x = p.x AND e.y = p.y
+	`, input.EstateId, input.PrevX, input.PrevY, input.X, input.Y, input.NextX, input.NextY).Return(mockRows, errAny)
 
 		output, err := repo.GetPrevNextTree(ctx, input)
 
@@ -326,10 +456,11 @@ func TestCreateTree(t *testing.T) {
 		}
 
 		input := CreateTreeInput{
-			Id:     "aaaaa-bbbbb-ccccc-ddddd",
-			X:      2,
-			Y:      3,
-			Height: 10,
+			Id:        "aaaaa-bbbbb-ccccc-ddddd",
+			X:         2,
+			Y:         3,
+			Height:    10,
+			SpeciesId: sql.NullString{String: "fffff-ggggg-hhhhh-iiiii", Valid: true},
 
 			EstateId:        "bbbbb-ccccc-ddddd-eeeee",
 			DroneDistFactor: 6,
@@ -344,12 +475,17 @@ func TestCreateTree(t *testing.T) {
 			max = CASE WHEN max < $1 THEN $1 ELSE max END,
 			min = CASE WHEN (min = 0 OR min > $1) THEN $1 ELSE min END,
 			drone_distance = drone_distance + $2,
-			median = 0,
+			median = NULL,
+			tree_version = tree_version + 1,
 			updated_at = NOW()
 		WHERE id = $3
 	`, input.Height, input.DroneDistFactor, input.EstateId).Return(mockRows, nil)
 		mockRows.EXPECT().Close()
-		mockTx.EXPECT().QueryContext(ctx, `INSERT INTO estate_trees (id, estate_id, x, y, height, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW())`, input.Id, input.EstateId, input.X, input.Y, input.Height).Return(mockRows, nil)
+		mockTx.EXPECT().QueryContext(ctx, `WITH tree AS (
+			INSERT INTO estate_trees (id, estate_id, species_id, x, y, height, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW()) RETURNING id, status
+		)
+		INSERT INTO tree_status_history (tree_id, to_status, changed_at) SELECT id, status, NOW() FROM tree
+	`, input.Id, input.EstateId, input.SpeciesId, input.X, input.Y, input.Height).Return(mockRows, nil)
 		mockRows.EXPECT().Close()
 		mockTx.EXPECT().Commit().Return(nil)
 
@@ -391,12 +527,17 @@ func TestCreateTree(t *testing.T) {
 			max = CASE WHEN max < $1 THEN $1 ELSE max END,
 			min = CASE WHEN (min = 0 OR min > $1) THEN $1 ELSE min END,
 			drone_distance = drone_distance + $2,
-			median = 0,
+			median = NULL,
+			tree_version = tree_version + 1,
 			updated_at = NOW()
 		WHERE id = $3
 	`, input.Height, input.DroneDistFactor, input.EstateId).Return(mockRows, nil)
 		mockRows.EXPECT().Close()
-		mockTx.EXPECT().QueryContext(ctx, `INSERT INTO estate_trees (id, estate_id, x, y, height, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW())`, input.Id, input.EstateId, input.X, input.Y, input.Height).Return(mockRows, nil)
+		mockTx.EXPECT().QueryContext(ctx, `WITH tree AS (
+			INSERT INTO estate_trees (id, estate_id, species_id, x, y, height, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW()) RETURNING id, status
+		)
+		INSERT INTO tree_status_history (tree_id, to_status, changed_at) SELECT id, status, NOW() FROM tree
+	`, input.Id, input.EstateId, input.SpeciesId, input.X, input.Y, input.Height).Return(mockRows, nil)
 		mockRows.EXPECT().Close()
 		mockTx.EXPECT().Commit().Return(errAny)
 
@@ -438,12 +579,17 @@ func TestCreateTree(t *testing.T) {
 			max = CASE WHEN max < $1 THEN $1 ELSE max END,
 			min = CASE WHEN (min = 0 OR min > $1) THEN $1 ELSE min END,
 			drone_distance = drone_distance + $2,
-			median = 0,
+			median = NULL,
+			tree_version = tree_version + 1,
 			updated_at = NOW()
 		WHERE id = $3
 	`, input.Height, input.DroneDistFactor, input.EstateId).Return(mockRows, nil)
 		mockRows.EXPECT().Close()
-		mockTx.EXPECT().QueryContext(ctx, `INSERT INTO estate_trees (id, estate_id, x, y, height, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW())`, input.Id, input.EstateId, input.X, input.Y, input.Height).Return(mockRows, errAny)
+		mockTx.EXPECT().QueryContext(ctx, `WITH tree AS (
+			INSERT INTO estate_trees (id, estate_id, species_id, x, y, height, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW()) RETURNING id, status
+		)
+		INSERT INTO tree_status_history (tree_id, to_status, changed_at) SELECT id, status, NOW() FROM tree
+	`, input.Id, input.EstateId, input.SpeciesId, input.X, input.Y, input.Height).Return(mockRows, errAny)
 
 		err := repo.CreateTree(ctx, input)
 
@@ -483,7 +629,8 @@ func TestCreateTree(t *testing.T) {
 			max = CASE WHEN max < $1 THEN $1 ELSE max END,
 			min = CASE WHEN (min = 0 OR min > $1) THEN $1 ELSE min END,
 			drone_distance = drone_distance + $2,
-			median = 0,
+			median = NULL,
+			tree_version = tree_version + 1,
 			updated_at = NOW()
 		WHERE id = $3
 	`, input.Height, input.DroneDistFactor, input.EstateId).Return(mockRows, errAny)
@@ -549,7 +696,7 @@ func TestGetHeightEstateTrees(t *testing.T) {
 		ctx := context.Background()
 
 		var height int
-		mockDb.EXPECT().QueryContext(ctx, `SELECT height FROM estate_trees WHERE estate_id = $1`, input.EstateId).Return(mockRows, nil)
+		mockDb.EXPECT().QueryContext(ctx, `SELECT height FROM estate_trees WHERE estate_id = $1 AND status <> 'felled'`, input.EstateId).Return(mockRows, nil)
 		mockRows.EXPECT().Next().Return(true)
 		mockRows.EXPECT().Scan(&height).Return(nil)
 		mockRows.EXPECT().Next().Return(false)
@@ -582,7 +729,7 @@ func TestGetHeightEstateTrees(t *testing.T) {
 		ctx := context.Background()
 
 		var height int
-		mockDb.EXPECT().QueryContext(ctx, `SELECT height FROM estate_trees WHERE estate_id = $1`, input.EstateId).Return(mockRows, nil)
+		mockDb.EXPECT().QueryContext(ctx, `SELECT height FROM estate_trees WHERE estate_id = $1 AND status <> 'felled'`, input.EstateId).Return(mockRows, nil)
 		mockRows.EXPECT().Next().Return(true)
 		mockRows.EXPECT().Scan(&height).Return(errAny)
 
@@ -613,7 +760,7 @@ func TestGetHeightEstateTrees(t *testing.T) {
 
 		ctx := context.Background()
 
-		mockDb.EXPECT().QueryContext(ctx, `SELECT height FROM estate_trees WHERE estate_id = $1`, input.EstateId).Return(mockRows, errAny)
+		mockDb.EXPECT().QueryContext(ctx, `SELECT height FROM estate_trees WHERE estate_id = $1 AND status <> 'felled'`, input.EstateId).Return(mockRows, errAny)
 
 		output, err := repo.GetHeightEstateTrees(ctx, input)
 
@@ -635,13 +782,14 @@ func TestStoreMedianEstate(t *testing.T) {
 		}
 
 		input := StoreMedianEstateInput{
-			EstateId: "aaaaa-bbbbb-ccccc-ddddd",
-			Median:   5.5,
+			EstateId:    "aaaaa-bbbbb-ccccc-ddddd",
+			Median:      5.5,
+			TreeVersion: 8,
 		}
 
 		ctx := context.Background()
 
-		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE estates SET median = $1 WHERE id = $2`, input.Median, input.EstateId).Return(mockRow)
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE estates SET median = $1 WHERE id = $2 AND tree_version = $3`, input.Median, input.EstateId, input.TreeVersion).Return(mockRow)
 		mockRow.EXPECT().Err().Return(nil)
 
 		err := repo.StoreMedianEstate(ctx, input)
@@ -663,13 +811,14 @@ func TestStoreMedianEstate(t *testing.T) {
 		errAny := errors.New("any error")
 
 		input := StoreMedianEstateInput{
-			EstateId: "aaaaa-bbbbb-ccccc-ddddd",
-			Median:   5.5,
+			EstateId:    "aaaaa-bbbbb-ccccc-ddddd",
+			Median:      5.5,
+			TreeVersion: 8,
 		}
 
 		ctx := context.Background()
 
-		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE estates SET median = $1 WHERE id = $2`, input.Median, input.EstateId).Return(mockRow)
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE estates SET median = $1 WHERE id = $2 AND tree_version = $3`, input.Median, input.EstateId, input.TreeVersion).Return(mockRow)
 		mockRow.EXPECT().Err().Return(errAny)
 
 		err := repo.StoreMedianEstate(ctx, input)
@@ -677,3 +826,2817 @@ func TestStoreMedianEstate(t *testing.T) {
 		assert.Equal(t, errAny, err)
 	})
 }
+
+func TestGetEstateTrees(t *testing.T) {
+	t.Run("Return the trees when no error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetEstateTreesInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			MinX:     1,
+			MaxX:     5,
+			MinY:     2,
+			MaxY:     2,
+		}
+
+		expOutput := GetEstateTreesOutput{
+			Trees: []EstateTree{
+				{Id: "aaaaa-bbbbb-ccccc-ddddd", X: 3, Y: 2, Height: 10, SpeciesId: sql.NullString{String: "fffff-ggggg-hhhhh-iiiii", Valid: true}, Status: "mature"},
+			},
+		}
+
+		ctx := context.Background()
+
+		var tree EstateTree
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, x, y, height, species_id, status FROM estate_trees WHERE estate_id = $1 AND status <> 'felled' AND x BETWEEN $2 AND $3 AND y BETWEEN $4 AND $5 ORDER BY y, x`, input.EstateId, input.MinX, input.MaxX, input.MinY, input.MaxY).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&tree.Id, &tree.X, &tree.Y, &tree.Height, &tree.SpeciesId, &tree.Status).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*string)) = expOutput.Trees[0].Id
+			*(args[1].(*int)) = expOutput.Trees[0].X
+			*(args[2].(*int)) = expOutput.Trees[0].Y
+			*(args[3].(*int)) = expOutput.Trees[0].Height
+			*(args[4].(*sql.NullString)) = expOutput.Trees[0].SpeciesId
+			*(args[5].(*string)) = expOutput.Trees[0].Status
+
+			return nil
+		})
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetEstateTrees(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetEstateTreesInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			MinX:     1,
+			MaxX:     5,
+			MinY:     1,
+			MaxY:     3,
+		}
+
+		ctx := context.Background()
+
+		var tree EstateTree
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, x, y, height, species_id, status FROM estate_trees WHERE estate_id = $1 AND status <> 'felled' AND x BETWEEN $2 AND $3 AND y BETWEEN $4 AND $5 ORDER BY y, x`, input.EstateId, input.MinX, input.MaxX, input.MinY, input.MaxY).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&tree.Id, &tree.X, &tree.Y, &tree.Height, &tree.SpeciesId, &tree.Status).Return(errAny)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetEstateTrees(ctx, input)
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetEstateTreesOutput{}, output)
+	})
+
+	t.Run("Return error when query context errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetEstateTreesInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			MinX:     1,
+			MaxX:     5,
+			MinY:     1,
+			MaxY:     3,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, x, y, height, species_id, status FROM estate_trees WHERE estate_id = $1 AND status <> 'felled' AND x BETWEEN $2 AND $3 AND y BETWEEN $4 AND $5 ORDER BY y, x`, input.EstateId, input.MinX, input.MaxX, input.MinY, input.MaxY).Return(nil, errAny)
+
+		output, err := repo.GetEstateTrees(ctx, input)
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetEstateTreesOutput{}, output)
+	})
+}
+
+func TestCreateObstacle(t *testing.T) {
+	t.Run("Return no error when insert is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := CreateObstacleInput{
+			EstateObstacle: EstateObstacle{
+				Id:          "aaaaa-bbbbb-ccccc-ddddd",
+				X:           2,
+				Y:           1,
+				Height:      15,
+				Description: "Water tower",
+			},
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO estate_obstacles (id, estate_id, x, y, height, no_fly, description, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())`, input.Id, input.EstateId, input.X, input.Y, input.Height, input.NoFly, input.Description).Return(mockRow)
+		mockRow.EXPECT().Err().Return(nil)
+
+		err := repo.CreateObstacle(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return error when row error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := CreateObstacleInput{
+			EstateObstacle: EstateObstacle{
+				Id:    "aaaaa-bbbbb-ccccc-ddddd",
+				X:     2,
+				Y:     1,
+				NoFly: true,
+			},
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO estate_obstacles (id, estate_id, x, y, height, no_fly, description, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())`, input.Id, input.EstateId, input.X, input.Y, input.Height, input.NoFly, input.Description).Return(mockRow)
+		mockRow.EXPECT().Err().Return(errAny)
+
+		err := repo.CreateObstacle(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestGetEstateObstacles(t *testing.T) {
+	t.Run("Return the obstacles when no error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetEstateObstaclesInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+		}
+
+		expOutput := GetEstateObstaclesOutput{
+			Obstacles: []EstateObstacle{
+				{Id: "aaaaa-bbbbb-ccccc-ddddd", X: 2, Y: 1, NoFly: true, Description: "Shed"},
+			},
+		}
+
+		ctx := context.Background()
+
+		var obstacle EstateObstacle
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, x, y, height, no_fly, description FROM estate_obstacles WHERE estate_id = $1 ORDER BY y, x`, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&obstacle.Id, &obstacle.X, &obstacle.Y, &obstacle.Height, &obstacle.NoFly, &obstacle.Description).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*string)) = expOutput.Obstacles[0].Id
+			*(args[1].(*int)) = expOutput.Obstacles[0].X
+			*(args[2].(*int)) = expOutput.Obstacles[0].Y
+			*(args[4].(*bool)) = expOutput.Obstacles[0].NoFly
+			*(args[5].(*string)) = expOutput.Obstacles[0].Description
+
+			return nil
+		})
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetEstateObstacles(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetEstateObstaclesInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+		}
+
+		ctx := context.Background()
+
+		var obstacle EstateObstacle
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, x, y, height, no_fly, description FROM estate_obstacles WHERE estate_id = $1 ORDER BY y, x`, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&obstacle.Id, &obstacle.X, &obstacle.Y, &obstacle.Height, &obstacle.NoFly, &obstacle.Description).Return(errAny)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetEstateObstacles(ctx, input)
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetEstateObstaclesOutput{}, output)
+	})
+
+	t.Run("Return error when query context errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetEstateObstaclesInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, x, y, height, no_fly, description FROM estate_obstacles WHERE estate_id = $1 ORDER BY y, x`, input.EstateId).Return(nil, errAny)
+
+		output, err := repo.GetEstateObstacles(ctx, input)
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetEstateObstaclesOutput{}, output)
+	})
+}
+
+func TestDeleteObstacle(t *testing.T) {
+	t.Run("Return no error when delete is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := DeleteObstacleInput{
+			Id:       "aaaaa-bbbbb-ccccc-ddddd",
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+		}
+
+		ctx := context.Background()
+
+		var id string
+		mockDb.EXPECT().QueryRowContext(ctx, `DELETE FROM estate_obstacles WHERE id = $1 AND estate_id = $2 RETURNING id`, input.Id, input.EstateId).Return(mockRow)
+		mockRow.EXPECT().Scan(&id).Return(nil)
+
+		err := repo.DeleteObstacle(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return error when no obstacle deleted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := DeleteObstacleInput{
+			Id:       "aaaaa-bbbbb-ccccc-ddddd",
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+		}
+
+		ctx := context.Background()
+
+		var id string
+		mockDb.EXPECT().QueryRowContext(ctx, `DELETE FROM estate_obstacles WHERE id = $1 AND estate_id = $2 RETURNING id`, input.Id, input.EstateId).Return(mockRow)
+		mockRow.EXPECT().Scan(&id).Return(sql.ErrNoRows)
+
+		err := repo.DeleteObstacle(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+}
+
+func TestGetEstateElevations(t *testing.T) {
+	t.Run("Return the elevations when no error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetEstateElevationsInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+		}
+
+		expOutput := GetEstateElevationsOutput{
+			Elevations: []PlotElevation{
+				{X: 2, Y: 1, Elevation: 5},
+			},
+		}
+
+		ctx := context.Background()
+
+		var elevation PlotElevation
+		mockDb.EXPECT().QueryContext(ctx, `SELECT x, y, elevation FROM estate_elevations WHERE estate_id = $1 ORDER BY y, x`, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&elevation.X, &elevation.Y, &elevation.Elevation).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*int)) = expOutput.Elevations[0].X
+			*(args[1].(*int)) = expOutput.Elevations[0].Y
+			*(args[2].(*int)) = expOutput.Elevations[0].Elevation
+
+			return nil
+		})
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetEstateElevations(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetEstateElevationsInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+		}
+
+		ctx := context.Background()
+
+		var elevation PlotElevation
+		mockDb.EXPECT().QueryContext(ctx, `SELECT x, y, elevation FROM estate_elevations WHERE estate_id = $1 ORDER BY y, x`, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&elevation.X, &elevation.Y, &elevation.Elevation).Return(errAny)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetEstateElevations(ctx, input)
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetEstateElevationsOutput{}, output)
+	})
+
+	t.Run("Return error when query context errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetEstateElevationsInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT x, y, elevation FROM estate_elevations WHERE estate_id = $1 ORDER BY y, x`, input.EstateId).Return(nil, errAny)
+
+		output, err := repo.GetEstateElevations(ctx, input)
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetEstateElevationsOutput{}, output)
+	})
+}
+
+func TestStoreEstateElevations(t *testing.T) {
+	t.Run("Return no error when store is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockTx := db.NewMockTx(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := StoreEstateElevationsInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			Elevations: []PlotElevation{
+				{X: 2, Y: 1, Elevation: 5},
+				{X: 3, Y: 1, Elevation: 8},
+			},
+			DroneDistFactor: 12,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().BeginTx(ctx, &sql.TxOptions{}).Return(mockTx, nil)
+		mockTx.EXPECT().Rollback()
+		mockTx.EXPECT().QueryContext(ctx, `DELETE FROM estate_elevations WHERE estate_id = $1`, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `INSERT INTO estate_elevations (estate_id, x, y, elevation, created_at, updated_at)
+		SELECT $1, e.x, e.y, e.elevation, NOW(), NOW() FROM unnest($2::BIGINT[], $3::BIGINT[], $4::BIGINT[]) AS e(x, y, elevation)
+	`, input.EstateId, pq.Int64Array{2, 3}, pq.Int64Array{1, 1}, pq.Int64Array{5, 8}).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `UPDATE estates SET drone_distance = drone_distance + $1, updated_at = NOW() WHERE id = $2`, input.DroneDistFactor, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().Commit().Return(nil)
+
+		err := repo.StoreEstateElevations(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return error when commit errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockTx := db.NewMockTx(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := StoreEstateElevationsInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			Elevations: []PlotElevation{
+				{X: 2, Y: 1, Elevation: 5},
+				{X: 3, Y: 1, Elevation: 8},
+			},
+			DroneDistFactor: 12,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().BeginTx(ctx, &sql.TxOptions{}).Return(mockTx, nil)
+		mockTx.EXPECT().Rollback()
+		mockTx.EXPECT().QueryContext(ctx, `DELETE FROM estate_elevations WHERE estate_id = $1`, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `INSERT INTO estate_elevations (estate_id, x, y, elevation, created_at, updated_at)
+		SELECT $1, e.x, e.y, e.elevation, NOW(), NOW() FROM unnest($2::BIGINT[], $3::BIGINT[], $4::BIGINT[]) AS e(x, y, elevation)
+	`, input.EstateId, pq.Int64Array{2, 3}, pq.Int64Array{1, 1}, pq.Int64Array{5, 8}).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `UPDATE estates SET drone_distance = drone_distance + $1, updated_at = NOW() WHERE id = $2`, input.DroneDistFactor, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().Commit().Return(errAny)
+
+		err := repo.StoreEstateElevations(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+
+	t.Run("Return error when query context of update estates errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockTx := db.NewMockTx(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := StoreEstateElevationsInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			Elevations: []PlotElevation{
+				{X: 2, Y: 1, Elevation: 5},
+				{X: 3, Y: 1, Elevation: 8},
+			},
+			DroneDistFactor: 12,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().BeginTx(ctx, &sql.TxOptions{}).Return(mockTx, nil)
+		mockTx.EXPECT().Rollback()
+		mockTx.EXPECT().QueryContext(ctx, `DELETE FROM estate_elevations WHERE estate_id = $1`, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `INSERT INTO estate_elevations (estate_id, x, y, elevation, created_at, updated_at)
+		SELECT $1, e.x, e.y, e.elevation, NOW(), NOW() FROM unnest($2::BIGINT[], $3::BIGINT[], $4::BIGINT[]) AS e(x, y, elevation)
+	`, input.EstateId, pq.Int64Array{2, 3}, pq.Int64Array{1, 1}, pq.Int64Array{5, 8}).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `UPDATE estates SET drone_distance = drone_distance + $1, updated_at = NOW() WHERE id = $2`, input.DroneDistFactor, input.EstateId).Return(mockRows, errAny)
+
+		err := repo.StoreEstateElevations(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+
+	t.Run("Return error when query context of insert estate elevations errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockTx := db.NewMockTx(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := StoreEstateElevationsInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			Elevations: []PlotElevation{
+				{X: 2, Y: 1, Elevation: 5},
+				{X: 3, Y: 1, Elevation: 8},
+			},
+			DroneDistFactor: 12,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().BeginTx(ctx, &sql.TxOptions{}).Return(mockTx, nil)
+		mockTx.EXPECT().Rollback()
+		mockTx.EXPECT().QueryContext(ctx, `DELETE FROM estate_elevations WHERE estate_id = $1`, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `INSERT INTO estate_elevations (estate_id, x, y, elevation, created_at, updated_at)
+		SELECT $1, e.x, e.y, e.elevation, NOW(), NOW() FROM unnest($2::BIGINT[], $3::BIGINT[], $4::BIGINT[]) AS e(x, y, elevation)
+	`, input.EstateId, pq.Int64Array{2, 3}, pq.Int64Array{1, 1}, pq.Int64Array{5, 8}).Return(mockRows, errAny)
+
+		err := repo.StoreEstateElevations(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+
+	t.Run("Return error when query context of delete estate elevations errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockTx := db.NewMockTx(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := StoreEstateElevationsInput{
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			Elevations: []PlotElevation{
+				{X: 2, Y: 1, Elevation: 5},
+				{X: 3, Y: 1, Elevation: 8},
+			},
+			DroneDistFactor: 12,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().BeginTx(ctx, &sql.TxOptions{}).Return(mockTx, nil)
+		mockTx.EXPECT().Rollback()
+		mockTx.EXPECT().QueryContext(ctx, `DELETE FROM estate_elevations WHERE estate_id = $1`, input.EstateId).Return(mockRows, errAny)
+
+		err := repo.StoreEstateElevations(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestStoreEstateHome(t *testing.T) {
+	t.Run("Return no error when update is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := StoreEstateHomeInput{
+			EstateId: "aaaaa-bbbbb-ccccc-ddddd",
+			X:        0,
+			Y:        3,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE estates SET home_x = $1, home_y = $2, updated_at = NOW() WHERE id = $3`, input.X, input.Y, input.EstateId).Return(mockRow)
+		mockRow.EXPECT().Err().Return(nil)
+
+		err := repo.StoreEstateHome(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return error when row error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := StoreEstateHomeInput{
+			EstateId: "aaaaa-bbbbb-ccccc-ddddd",
+			X:        0,
+			Y:        3,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE estates SET home_x = $1, home_y = $2, updated_at = NOW() WHERE id = $3`, input.X, input.Y, input.EstateId).Return(mockRow)
+		mockRow.EXPECT().Err().Return(errAny)
+
+		err := repo.StoreEstateHome(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestStoreEstateCeiling(t *testing.T) {
+	t.Run("Return no error when update is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := StoreEstateCeilingInput{
+			EstateId: "aaaaa-bbbbb-ccccc-ddddd",
+			Ceiling:  60,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE estates SET ceiling = $1, updated_at = NOW() WHERE id = $2`, input.Ceiling, input.EstateId).Return(mockRow)
+		mockRow.EXPECT().Err().Return(nil)
+
+		err := repo.StoreEstateCeiling(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return error when row error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := StoreEstateCeilingInput{
+			EstateId: "aaaaa-bbbbb-ccccc-ddddd",
+			Ceiling:  60,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE estates SET ceiling = $1, updated_at = NOW() WHERE id = $2`, input.Ceiling, input.EstateId).Return(mockRow)
+		mockRow.EXPECT().Err().Return(errAny)
+
+		err := repo.StoreEstateCeiling(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestCreateDrone(t *testing.T) {
+	t.Run("Return no error when insert is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := CreateDroneInput{
+			Drone: Drone{
+				Id:              "aaaaa-bbbbb-ccccc-ddddd",
+				Model:           "Surveyor X1",
+				MaxRange:        5000,
+				MaxAltitude:     120,
+				CruiseSpeed:     12,
+				ClimbRate:       4,
+				BatteryCapacity: 90,
+			},
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO drones (id, model, max_range, max_altitude, cruise_speed, climb_rate, battery_capacity, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())`, input.Id, input.Model, input.MaxRange, input.MaxAltitude, input.CruiseSpeed, input.ClimbRate, input.BatteryCapacity).Return(mockRow)
+		mockRow.EXPECT().Err().Return(nil)
+
+		err := repo.CreateDrone(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return error when row error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := CreateDroneInput{
+			Drone: Drone{
+				Id:    "aaaaa-bbbbb-ccccc-ddddd",
+				Model: "Surveyor X1",
+			},
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO drones (id, model, max_range, max_altitude, cruise_speed, climb_rate, battery_capacity, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())`, input.Id, input.Model, input.MaxRange, input.MaxAltitude, input.CruiseSpeed, input.ClimbRate, input.BatteryCapacity).Return(mockRow)
+		mockRow.EXPECT().Err().Return(errAny)
+
+		err := repo.CreateDrone(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestGetDroneById(t *testing.T) {
+	t.Run("Return the drone when get is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetDroneByIdInput{
+			Id: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		expOutput := GetDroneByIdOutput{
+			Drone: Drone{
+				Id:              input.Id,
+				Model:           "Surveyor X1",
+				MaxRange:        5000,
+				MaxAltitude:     120,
+				CruiseSpeed:     12,
+				ClimbRate:       4,
+				BatteryCapacity: 90,
+			},
+		}
+
+		ctx := context.Background()
+
+		var output GetDroneByIdOutput
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, model, max_range, max_altitude, cruise_speed, climb_rate, battery_capacity FROM drones WHERE id = $1`, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(&output.Id, &output.Model, &output.MaxRange, &output.MaxAltitude, &output.CruiseSpeed, &output.ClimbRate, &output.BatteryCapacity).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*string)) = expOutput.Id
+			*(args[1].(*string)) = expOutput.Model
+			*(args[2].(*int)) = expOutput.MaxRange
+			*(args[3].(*int)) = expOutput.MaxAltitude
+			*(args[4].(*float64)) = expOutput.CruiseSpeed
+			*(args[5].(*float64)) = expOutput.ClimbRate
+			*(args[6].(*float64)) = expOutput.BatteryCapacity
+
+			return nil
+		})
+
+		output, err := repo.GetDroneById(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetDroneByIdInput{
+			Id: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		ctx := context.Background()
+
+		var output GetDroneByIdOutput
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, model, max_range, max_altitude, cruise_speed, climb_rate, battery_capacity FROM drones WHERE id = $1`, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(&output.Id, &output.Model, &output.MaxRange, &output.MaxAltitude, &output.CruiseSpeed, &output.ClimbRate, &output.BatteryCapacity).Return(sql.ErrNoRows)
+
+		output, err := repo.GetDroneById(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+		assert.Equal(t, GetDroneByIdOutput{}, output)
+	})
+}
+
+func TestGetDrones(t *testing.T) {
+	t.Run("Return the drones when no error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		expOutput := GetDronesOutput{
+			Drones: []Drone{
+				{Id: "aaaaa-bbbbb-ccccc-ddddd", Model: "Surveyor X1", MaxRange: 5000, MaxAltitude: 120, CruiseSpeed: 12, ClimbRate: 4, BatteryCapacity: 90},
+			},
+		}
+
+		ctx := context.Background()
+
+		var drone Drone
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, model, max_range, max_altitude, cruise_speed, climb_rate, battery_capacity FROM drones ORDER BY created_at, id`).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&drone.Id, &drone.Model, &drone.MaxRange, &drone.MaxAltitude, &drone.CruiseSpeed, &drone.ClimbRate, &drone.BatteryCapacity).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*string)) = expOutput.Drones[0].Id
+			*(args[1].(*string)) = expOutput.Drones[0].Model
+			*(args[2].(*int)) = expOutput.Drones[0].MaxRange
+			*(args[3].(*int)) = expOutput.Drones[0].MaxAltitude
+			*(args[4].(*float64)) = expOutput.Drones[0].CruiseSpeed
+			*(args[5].(*float64)) = expOutput.Drones[0].ClimbRate
+			*(args[6].(*float64)) = expOutput.Drones[0].BatteryCapacity
+
+			return nil
+		})
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetDrones(ctx, GetDronesInput{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		ctx := context.Background()
+
+		var drone Drone
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, model, max_range, max_altitude, cruise_speed, climb_rate, battery_capacity FROM drones ORDER BY created_at, id`).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&drone.Id, &drone.Model, &drone.MaxRange, &drone.MaxAltitude, &drone.CruiseSpeed, &drone.ClimbRate, &drone.BatteryCapacity).Return(errAny)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetDrones(ctx, GetDronesInput{})
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetDronesOutput{}, output)
+	})
+
+	t.Run("Return error when query context errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, model, max_range, max_altitude, cruise_speed, climb_rate, battery_capacity FROM drones ORDER BY created_at, id`).Return(nil, errAny)
+
+		output, err := repo.GetDrones(ctx, GetDronesInput{})
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetDronesOutput{}, output)
+	})
+}
+
+func TestUpdateDrone(t *testing.T) {
+	t.Run("Return no error when update is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := UpdateDroneInput{
+			Drone: Drone{
+				Id:              "aaaaa-bbbbb-ccccc-ddddd",
+				Model:           "Surveyor X2",
+				MaxRange:        6000,
+				MaxAltitude:     120,
+				CruiseSpeed:     14,
+				ClimbRate:       5,
+				BatteryCapacity: 110,
+			},
+		}
+
+		ctx := context.Background()
+
+		var id string
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE drones SET model = $1, max_range = $2, max_altitude = $3, cruise_speed = $4, climb_rate = $5, battery_capacity = $6, updated_at = NOW() WHERE id = $7 RETURNING id`, input.Model, input.MaxRange, input.MaxAltitude, input.CruiseSpeed, input.ClimbRate, input.BatteryCapacity, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(&id).Return(nil)
+
+		err := repo.UpdateDrone(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return error when no drone updated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := UpdateDroneInput{
+			Drone: Drone{
+				Id:    "aaaaa-bbbbb-ccccc-ddddd",
+				Model: "Surveyor X2",
+			},
+		}
+
+		ctx := context.Background()
+
+		var id string
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE drones SET model = $1, max_range = $2, max_altitude = $3, cruise_speed = $4, climb_rate = $5, battery_capacity = $6, updated_at = NOW() WHERE id = $7 RETURNING id`, input.Model, input.MaxRange, input.MaxAltitude, input.CruiseSpeed, input.ClimbRate, input.BatteryCapacity, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(&id).Return(sql.ErrNoRows)
+
+		err := repo.UpdateDrone(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+}
+
+func TestDeleteDrone(t *testing.T) {
+	t.Run("Return no error when delete is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := DeleteDroneInput{
+			Id: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		ctx := context.Background()
+
+		var id string
+		mockDb.EXPECT().QueryRowContext(ctx, `DELETE FROM drones WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM missions WHERE drone_id = $1 AND status IN ('scheduled', 'in_progress')) RETURNING id`, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(&id).Return(nil)
+
+		err := repo.DeleteDrone(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return error when no drone deleted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := DeleteDroneInput{
+			Id: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		ctx := context.Background()
+
+		var id string
+		mockDb.EXPECT().QueryRowContext(ctx, `DELETE FROM drones WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM missions WHERE drone_id = $1 AND status IN ('scheduled', 'in_progress')) RETURNING id`, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(&id).Return(sql.ErrNoRows)
+
+		err := repo.DeleteDrone(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+}
+
+func TestCreateDronePlanSnapshot(t *testing.T) {
+	t.Run("Return the version when insert is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := CreateDronePlanSnapshotInput{
+			DronePlanSnapshot: DronePlanSnapshot{
+				Id:         "aaaaa-bbbbb-ccccc-ddddd",
+				EstateId:   "eeeee-fffff-ggggg-hhhhh",
+				Mode:       "canopy_following",
+				TreeHash:   "abcdef",
+				Distance:   42,
+				FlightTime: 3.5,
+				Energy:     0.25,
+				Waypoints: []PlanWaypoint{
+					{X: 1, Y: 1, Altitude: 11},
+					{X: 2, Y: 1, Altitude: 1, Transit: true},
+				},
+			},
+		}
+
+		expOutput := CreateDronePlanSnapshotOutput{
+			Version:   3,
+			CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		ctx := context.Background()
+
+		var output CreateDronePlanSnapshotOutput
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO drone_plan_snapshots (id, estate_id, version, mode, tree_hash, distance, flight_time, energy, waypoints, created_at)
+		SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6, $7, $8, NOW() FROM drone_plan_snapshots WHERE estate_id = $2
+		RETURNING version, created_at
+	`, input.Id, input.EstateId, input.Mode, input.TreeHash, input.Distance, input.FlightTime, input.Energy, []byte(`[{"x":1,"y":1,"altitude":11},{"x":2,"y":1,"altitude":1,"transit":true}]`)).Return(mockRow)
+		mockRow.EXPECT().Scan(&output.Version, &output.CreatedAt).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*int)) = expOutput.Version
+			*(args[1].(*time.Time)) = expOutput.CreatedAt
+
+			return nil
+		})
+
+		output, err := repo.CreateDronePlanSnapshot(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := CreateDronePlanSnapshotInput{
+			DronePlanSnapshot: DronePlanSnapshot{
+				Id:       "aaaaa-bbbbb-ccccc-ddddd",
+				EstateId: "eeeee-fffff-ggggg-hhhhh",
+			},
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO drone_plan_snapshots (id, estate_id, version, mode, tree_hash, distance, flight_time, energy, waypoints, created_at)
+		SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6, $7, $8, NOW() FROM drone_plan_snapshots WHERE estate_id = $2
+		RETURNING version, created_at
+	`, input.Id, input.EstateId, input.Mode, input.TreeHash, input.Distance, input.FlightTime, input.Energy, []byte("null")).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).Return(errAny)
+
+		_, err := repo.CreateDronePlanSnapshot(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestGetDronePlanSnapshot(t *testing.T) {
+	t.Run("Return the snapshot when get is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetDronePlanSnapshotInput{
+			EstateId: "eeeee-fffff-ggggg-hhhhh",
+		}
+
+		expOutput := GetDronePlanSnapshotOutput{
+			DronePlanSnapshot: DronePlanSnapshot{
+				Id:         "aaaaa-bbbbb-ccccc-ddddd",
+				EstateId:   input.EstateId,
+				Version:    2,
+				Mode:       "coverage",
+				TreeHash:   "abcdef",
+				Distance:   42,
+				FlightTime: 3.5,
+				Energy:     0.25,
+				Waypoints: []PlanWaypoint{
+					{X: 1, Y: 1, Altitude: 11},
+					{X: 2, Y: 1, Altitude: 1, Transit: true},
+				},
+				CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		}
+
+		ctx := context.Background()
+
+		var output GetDronePlanSnapshotOutput
+		var waypoints []byte
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, estate_id, version, mode, tree_hash, distance, flight_time, energy, waypoints, created_at FROM drone_plan_snapshots
+		WHERE estate_id = $1 AND ($2::BIGINT = 0 OR version = $2) ORDER BY version DESC LIMIT 1
+	`, input.EstateId, input.Version).Return(mockRow)
+		mockRow.EXPECT().Scan(&output.Id, &output.EstateId, &output.Version, &output.Mode, &output.TreeHash, &output.Distance, &output.FlightTime, &output.Energy, &waypoints, &output.CreatedAt).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*string)) = expOutput.Id
+			*(args[1].(*string)) = expOutput.EstateId
+			*(args[2].(*int)) = expOutput.Version
+			*(args[3].(*string)) = expOutput.Mode
+			*(args[4].(*string)) = expOutput.TreeHash
+			*(args[5].(*int)) = expOutput.Distance
+			*(args[6].(*float64)) = expOutput.FlightTime
+			*(args[7].(*float64)) = expOutput.Energy
+			*(args[8].(*[]byte)) = []byte(`[{"x":1,"y":1,"altitude":11},{"x":2,"y":1,"altitude":1,"transit":true}]`)
+			*(args[9].(*time.Time)) = expOutput.CreatedAt
+
+			return nil
+		})
+
+		output, err := repo.GetDronePlanSnapshot(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when the waypoints are not valid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetDronePlanSnapshotInput{
+			EstateId: "eeeee-fffff-ggggg-hhhhh",
+			Version:  1,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, estate_id, version, mode, tree_hash, distance, flight_time, energy, waypoints, created_at FROM drone_plan_snapshots
+		WHERE estate_id = $1 AND ($2::BIGINT = 0 OR version = $2) ORDER BY version DESC LIMIT 1
+	`, input.EstateId, input.Version).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[8].(*[]byte)) = []byte(`{`)
+
+			return nil
+		})
+
+		output, err := repo.GetDronePlanSnapshot(ctx, input)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, GetDronePlanSnapshotOutput{}, output)
+	})
+
+	t.Run("Return error when the snapshot is missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetDronePlanSnapshotInput{
+			EstateId: "eeeee-fffff-ggggg-hhhhh",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, estate_id, version, mode, tree_hash, distance, flight_time, energy, waypoints, created_at FROM drone_plan_snapshots
+		WHERE estate_id = $1 AND ($2::BIGINT = 0 OR version = $2) ORDER BY version DESC LIMIT 1
+	`, input.EstateId, input.Version).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+
+		_, err := repo.GetDronePlanSnapshot(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+}
+
+func TestCreateMission(t *testing.T) {
+	t.Run("Return the creation time when insert is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := CreateMissionInput{
+			Mission: Mission{
+				Id:         "aaaaa-bbbbb-ccccc-ddddd",
+				EstateId:   "eeeee-fffff-ggggg-hhhhh",
+				SnapshotId: "iiiii-jjjjj-kkkkk-lllll",
+				Status:     "scheduled",
+			},
+		}
+
+		expOutput := CreateMissionOutput{
+			CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+
+		ctx := context.Background()
+
+		var output CreateMissionOutput
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO missions (id, estate_id, snapshot_id, drone_id, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING created_at`, input.Id, input.EstateId, input.SnapshotId, input.DroneId, input.Status).Return(mockRow)
+		mockRow.EXPECT().Scan(&output.CreatedAt).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*time.Time)) = expOutput.CreatedAt
+
+			return nil
+		})
+
+		output, err := repo.CreateMission(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := CreateMissionInput{
+			Mission: Mission{
+				Id:       "aaaaa-bbbbb-ccccc-ddddd",
+				EstateId: "eeeee-fffff-ggggg-hhhhh",
+			},
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO missions (id, estate_id, snapshot_id, drone_id, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING created_at`, input.Id, input.EstateId, input.SnapshotId, input.DroneId, input.Status).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any()).Return(errAny)
+
+		_, err := repo.CreateMission(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestGetMissionById(t *testing.T) {
+	t.Run("Return the mission when get is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		exp := Mission{
+			Id:              "aaaaa-bbbbb-ccccc-ddddd",
+			EstateId:        "eeeee-fffff-ggggg-hhhhh",
+			SnapshotId:      "iiiii-jjjjj-kkkkk-lllll",
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "mmmmm-nnnnn-ooooo-ppppp", Valid: true},
+			Status:          "aborted",
+			AbortReason:     sql.NullString{String: "strong wind", Valid: true},
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			StartedAt:       sql.NullTime{Time: time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC), Valid: true},
+			EndedAt:         sql.NullTime{Time: time.Date(2024, 1, 2, 4, 30, 0, 0, time.UTC), Valid: true},
+		}
+		input := GetMissionByIdInput{
+			Id: exp.Id,
+		}
+
+		ctx := context.Background()
+
+		var output GetMissionByIdOutput
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT m.id, m.estate_id, m.snapshot_id, s.version, m.drone_id, m.status, m.abort_reason, m.created_at, m.started_at, m.ended_at
+		FROM missions m JOIN drone_plan_snapshots s ON s.id = m.snapshot_id
+		WHERE m.id = $1
+	`, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(&output.Id, &output.EstateId, &output.SnapshotId, &output.SnapshotVersion, &output.DroneId, &output.Status, &output.AbortReason, &output.CreatedAt, &output.StartedAt, &output.EndedAt).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*string)) = exp.Id
+			*(args[1].(*string)) = exp.EstateId
+			*(args[2].(*string)) = exp.SnapshotId
+			*(args[3].(*int)) = exp.SnapshotVersion
+			*(args[4].(*sql.NullString)) = exp.DroneId
+			*(args[5].(*string)) = exp.Status
+			*(args[6].(*sql.NullString)) = exp.AbortReason
+			*(args[7].(*time.Time)) = exp.CreatedAt
+			*(args[8].(*sql.NullTime)) = exp.StartedAt
+			*(args[9].(*sql.NullTime)) = exp.EndedAt
+
+			return nil
+		})
+
+		output, err := repo.GetMissionById(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, GetMissionByIdOutput{Mission: exp}, output)
+	})
+
+	t.Run("Return error when scan error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetMissionByIdInput{
+			Id: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT m.id, m.estate_id, m.snapshot_id, s.version, m.drone_id, m.status, m.abort_reason, m.created_at, m.started_at, m.ended_at
+		FROM missions m JOIN drone_plan_snapshots s ON s.id = m.snapshot_id
+		WHERE m.id = $1
+	`, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+
+		_, err := repo.GetMissionById(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+}
+
+func TestGetMissions(t *testing.T) {
+	t.Run("Return the missions when no error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		exp := Mission{
+			Id:              "aaaaa-bbbbb-ccccc-ddddd",
+			EstateId:        "eeeee-fffff-ggggg-hhhhh",
+			SnapshotId:      "iiiii-jjjjj-kkkkk-lllll",
+			SnapshotVersion: 2,
+			DroneId:         sql.NullString{String: "mmmmm-nnnnn-ooooo-ppppp", Valid: true},
+			Status:          "aborted",
+			AbortReason:     sql.NullString{String: "strong wind", Valid: true},
+			CreatedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			StartedAt:       sql.NullTime{Time: time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC), Valid: true},
+			EndedAt:         sql.NullTime{Time: time.Date(2024, 1, 2, 4, 30, 0, 0, time.UTC), Valid: true},
+		}
+		input := GetMissionsInput{
+			EstateId: exp.EstateId,
+			Status:   "aborted",
+		}
+
+		ctx := context.Background()
+
+		var mission Mission
+		mockDb.EXPECT().QueryContext(ctx, `SELECT m.id, m.estate_id, m.snapshot_id, s.version, m.drone_id, m.status, m.abort_reason, m.created_at, m.started_at, m.ended_at
+		FROM missions m JOIN drone_plan_snapshots s ON s.id = m.snapshot_id
+		WHERE ($1 = '' OR m.estate_id = $1) AND ($2 = '' OR m.status = $2)
+		ORDER BY m.created_at, m.id
+	`, input.EstateId, input.Status).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&mission.Id, &mission.EstateId, &mission.SnapshotId, &mission.SnapshotVersion, &mission.DroneId, &mission.Status, &mission.AbortReason, &mission.CreatedAt, &mission.StartedAt, &mission.EndedAt).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*string)) = exp.Id
+			*(args[1].(*string)) = exp.EstateId
+			*(args[2].(*string)) = exp.SnapshotId
+			*(args[3].(*int)) = exp.SnapshotVersion
+			*(args[4].(*sql.NullString)) = exp.DroneId
+			*(args[5].(*string)) = exp.Status
+			*(args[6].(*sql.NullString)) = exp.AbortReason
+			*(args[7].(*time.Time)) = exp.CreatedAt
+			*(args[8].(*sql.NullTime)) = exp.StartedAt
+			*(args[9].(*sql.NullTime)) = exp.EndedAt
+
+			return nil
+		})
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetMissions(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, GetMissionsOutput{Missions: []Mission{exp}}, output)
+	})
+
+	t.Run("Return error when scan errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT m.id, m.estate_id, m.snapshot_id, s.version, m.drone_id, m.status, m.abort_reason, m.created_at, m.started_at, m.ended_at
+		FROM missions m JOIN drone_plan_snapshots s ON s.id = m.snapshot_id
+		WHERE ($1 = '' OR m.estate_id = $1) AND ($2 = '' OR m.status = $2)
+		ORDER BY m.created_at, m.id
+	`, "", "").Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errAny)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetMissions(ctx, GetMissionsInput{})
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetMissionsOutput{}, output)
+	})
+
+	t.Run("Return error when query context errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT m.id, m.estate_id, m.snapshot_id, s.version, m.drone_id, m.status, m.abort_reason, m.created_at, m.started_at, m.ended_at
+		FROM missions m JOIN drone_plan_snapshots s ON s.id = m.snapshot_id
+		WHERE ($1 = '' OR m.estate_id = $1) AND ($2 = '' OR m.status = $2)
+		ORDER BY m.created_at, m.id
+	`, "", "").Return(nil, errAny)
+
+		_, err := repo.GetMissions(ctx, GetMissionsInput{})
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestUpdateMissionDrone(t *testing.T) {
+	t.Run("Return no error when update is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := UpdateMissionDroneInput{
+			Id:      "aaaaa-bbbbb-ccccc-ddddd",
+			DroneId: "mmmmm-nnnnn-ooooo-ppppp",
+		}
+
+		ctx := context.Background()
+
+		var id string
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE missions SET drone_id = $1, updated_at = NOW() WHERE id = $2 AND status = 'scheduled' RETURNING id`, input.DroneId, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(&id).Return(nil)
+
+		err := repo.UpdateMissionDrone(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return error when the mission is not scheduled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := UpdateMissionDroneInput{
+			Id:      "aaaaa-bbbbb-ccccc-ddddd",
+			DroneId: "mmmmm-nnnnn-ooooo-ppppp",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE missions SET drone_id = $1, updated_at = NOW() WHERE id = $2 AND status = 'scheduled' RETURNING id`, input.DroneId, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+		err := repo.UpdateMissionDrone(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+}
+
+func TestUpdateMissionStatus(t *testing.T) {
+	t.Run("Return the timestamps when update is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := UpdateMissionStatusInput{
+			Id:   "aaaaa-bbbbb-ccccc-ddddd",
+			From: "scheduled",
+			To:   "in_progress",
+		}
+
+		expOutput := UpdateMissionStatusOutput{
+			StartedAt: sql.NullTime{Time: time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC), Valid: true},
+		}
+
+		ctx := context.Background()
+
+		var output UpdateMissionStatusOutput
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE missions SET status = $1::VARCHAR, abort_reason = $2,
+			started_at = CASE WHEN $1::VARCHAR = 'in_progress' THEN NOW() ELSE started_at END,
+			ended_at = CASE WHEN $1::VARCHAR IN ('completed', 'aborted') THEN NOW() ELSE ended_at END,
+			updated_at = NOW()
+		WHERE id = $3 AND status = $4 RETURNING started_at, ended_at
+	`, input.To, input.AbortReason, input.Id, input.From).Return(mockRow)
+		mockRow.EXPECT().Scan(&output.StartedAt, &output.EndedAt).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*sql.NullTime)) = expOutput.StartedAt
+
+			return nil
+		})
+
+		output, err := repo.UpdateMissionStatus(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when the mission moved in the meantime", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := UpdateMissionStatusInput{
+			Id:          "aaaaa-bbbbb-ccccc-ddddd",
+			From:        "in_progress",
+			To:          "aborted",
+			AbortReason: sql.NullString{String: "strong wind", Valid: true},
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `UPDATE missions SET status = $1::VARCHAR, abort_reason = $2,
+			started_at = CASE WHEN $1::VARCHAR = 'in_progress' THEN NOW() ELSE started_at END,
+			ended_at = CASE WHEN $1::VARCHAR IN ('completed', 'aborted') THEN NOW() ELSE ended_at END,
+			updated_at = NOW()
+		WHERE id = $3 AND status = $4 RETURNING started_at, ended_at
+	`, input.To, input.AbortReason, input.Id, input.From).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+
+		_, err := repo.UpdateMissionStatus(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+}
+
+func TestStoreMissionTelemetry(t *testing.T) {
+	t.Run("Return the stored count when insert is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := StoreMissionTelemetryInput{
+			MissionId: "aaaaa-bbbbb-ccccc-ddddd",
+			Points: []TelemetryPoint{
+				{RecordedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), X: 1, Y: 1, Altitude: 11, Battery: 98.5},
+				{RecordedAt: time.Date(2024, 1, 2, 3, 4, 6, 500000000, time.UTC), X: 2, Y: 1, Altitude: 6.5, Battery: 98},
+			},
+		}
+
+		ctx := context.Background()
+
+		var output StoreMissionTelemetryOutput
+		mockDb.EXPECT().QueryRowContext(ctx, `WITH stored AS (
+			INSERT INTO mission_telemetry (mission_id, recorded_at, x, y, altitude, battery)
+			SELECT $1, p.recorded_at, p.x, p.y, p.altitude, p.battery FROM unnest($2::TIMESTAMPTZ[], $3::BIGINT[], $4::BIGINT[], $5::DOUBLE PRECISION[], $6::DOUBLE PRECISION[]) AS p(recorded_at, x, y, altitude, battery)
+			ON CONFLICT (mission_id, recorded_at) DO NOTHING
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM stored
+	`, input.MissionId, pq.StringArray{"2024-01-02T03:04:05Z", "2024-01-02T03:04:06.5Z"}, pq.Int64Array{1, 2}, pq.Int64Array{1, 1}, pq.Float64Array{11, 6.5}, pq.Float64Array{98.5, 98}).Return(mockRow)
+		mockRow.EXPECT().Scan(&output.Stored).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*int)) = 2
+
+			return nil
+		})
+
+		output, err := repo.StoreMissionTelemetry(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, StoreMissionTelemetryOutput{Stored: 2}, output)
+	})
+
+	t.Run("Return error when scan error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := StoreMissionTelemetryInput{
+			MissionId: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `WITH stored AS (
+			INSERT INTO mission_telemetry (mission_id, recorded_at, x, y, altitude, battery)
+			SELECT $1, p.recorded_at, p.x, p.y, p.altitude, p.battery FROM unnest($2::TIMESTAMPTZ[], $3::BIGINT[], $4::BIGINT[], $5::DOUBLE PRECISION[], $6::DOUBLE PRECISION[]) AS p(recorded_at, x, y, altitude, battery)
+			ON CONFLICT (mission_id, recorded_at) DO NOTHING
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM stored
+	`, input.MissionId, pq.StringArray(nil), pq.Int64Array(nil), pq.Int64Array(nil), pq.Float64Array(nil), pq.Float64Array(nil)).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any()).Return(errAny)
+
+		_, err := repo.StoreMissionTelemetry(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestGetMissionTelemetry(t *testing.T) {
+	t.Run("Return the points when no error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetMissionTelemetryInput{
+			MissionId: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		expOutput := GetMissionTelemetryOutput{
+			Points: []TelemetryPoint{
+				{RecordedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), X: 1, Y: 1, Altitude: 11, Battery: 98.5},
+			},
+		}
+
+		ctx := context.Background()
+
+		var point TelemetryPoint
+		mockDb.EXPECT().QueryContext(ctx, `SELECT recorded_at, x, y, altitude, battery FROM mission_telemetry WHERE mission_id = $1 ORDER BY recorded_at`, input.MissionId).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&point.RecordedAt, &point.X, &point.Y, &point.Altitude, &point.Battery).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*time.Time)) = expOutput.Points[0].RecordedAt
+			*(args[1].(*int)) = expOutput.Points[0].X
+			*(args[2].(*int)) = expOutput.Points[0].Y
+			*(args[3].(*float64)) = expOutput.Points[0].Altitude
+			*(args[4].(*float64)) = expOutput.Points[0].Battery
+
+			return nil
+		})
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetMissionTelemetry(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetMissionTelemetryInput{
+			MissionId: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT recorded_at, x, y, altitude, battery FROM mission_telemetry WHERE mission_id = $1 ORDER BY recorded_at`, input.MissionId).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errAny)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetMissionTelemetry(ctx, input)
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetMissionTelemetryOutput{}, output)
+	})
+
+	t.Run("Return error when query context errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetMissionTelemetryInput{
+			MissionId: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT recorded_at, x, y, altitude, battery FROM mission_telemetry WHERE mission_id = $1 ORDER BY recorded_at`, input.MissionId).Return(nil, errAny)
+
+		_, err := repo.GetMissionTelemetry(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestGetTreeById(t *testing.T) {
+	t.Run("Return the tree when found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetTreeByIdInput{
+			Id:       "aaaaa-bbbbb-ccccc-ddddd",
+			EstateId: "eeeee-fffff-ggggg-hhhhh",
+		}
+
+		expOutput := GetTreeByIdOutput{
+			EstateTree: EstateTree{Id: input.Id, X: 2, Y: 1, Height: 10, Status: "felled"},
+		}
+
+		ctx := context.Background()
+
+		var output GetTreeByIdOutput
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, x, y, height, species_id, status FROM estate_trees WHERE id = $1 AND estate_id = $2`, input.Id, input.EstateId).Return(mockRow)
+		mockRow.EXPECT().Scan(&output.Id, &output.X, &output.Y, &output.Height, &output.SpeciesId, &output.Status).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*string)) = expOutput.Id
+			*(args[1].(*int)) = expOutput.X
+			*(args[2].(*int)) = expOutput.Y
+			*(args[3].(*int)) = expOutput.Height
+			*(args[5].(*string)) = expOutput.Status
+
+			return nil
+		})
+
+		output, err := repo.GetTreeById(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetTreeByIdInput{
+			Id:       "aaaaa-bbbbb-ccccc-ddddd",
+			EstateId: "eeeee-fffff-ggggg-hhhhh",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, x, y, height, species_id, status FROM estate_trees WHERE id = $1 AND estate_id = $2`, input.Id, input.EstateId).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+
+		_, err := repo.GetTreeById(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+}
+
+func TestCreateTreeObservation(t *testing.T) {
+	t.Run("Return no error when insert is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := CreateTreeObservationInput{
+			Id:               "aaaaa-bbbbb-ccccc-ddddd",
+			TreeId:           "iiiii-jjjjj-kkkkk-lllll",
+			HealthScore:      4,
+			SuspectedDisease: sql.NullString{String: "leaf rust", Valid: true},
+			Pests:            []string{"aphid", "mite"},
+			ObservedAt:       sql.NullTime{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true},
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO tree_observations (id, tree_id, mission_id, health_score, suspected_disease, pests, fruit_count, notes, observed_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, '{}'), $7, $8, COALESCE($9, NOW()), NOW())
+	`, input.Id, input.TreeId, input.MissionId, input.HealthScore, input.SuspectedDisease, pq.StringArray{"aphid", "mite"}, input.FruitCount, input.Notes, input.ObservedAt).Return(mockRow)
+		mockRow.EXPECT().Err().Return(nil)
+
+		err := repo.CreateTreeObservation(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return error when row error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := CreateTreeObservationInput{
+			Id:     "aaaaa-bbbbb-ccccc-ddddd",
+			TreeId: "iiiii-jjjjj-kkkkk-lllll",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO tree_observations (id, tree_id, mission_id, health_score, suspected_disease, pests, fruit_count, notes, observed_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, '{}'), $7, $8, COALESCE($9, NOW()), NOW())
+	`, input.Id, input.TreeId, input.MissionId, input.HealthScore, input.SuspectedDisease, pq.StringArray(nil), input.FruitCount, input.Notes, input.ObservedAt).Return(mockRow)
+		mockRow.EXPECT().Err().Return(errAny)
+
+		err := repo.CreateTreeObservation(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestGetTreeObservations(t *testing.T) {
+	t.Run("Return the observations when no error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetTreeObservationsInput{
+			EstateId: "eeeee-fffff-ggggg-hhhhh",
+			TreeId:   "iiiii-jjjjj-kkkkk-lllll",
+		}
+
+		expOutput := GetTreeObservationsOutput{
+			Observations: []TreeObservation{
+				{
+					Id:               "aaaaa-bbbbb-ccccc-ddddd",
+					TreeId:           "iiiii-jjjjj-kkkkk-lllll",
+					X:                2,
+					Y:                1,
+					MissionId:        sql.NullString{String: "mmmmm-nnnnn-ooooo-ppppp", Valid: true},
+					HealthScore:      4,
+					SuspectedDisease: sql.NullString{String: "leaf rust", Valid: true},
+					Pests:            []string{"aphid"},
+					FruitCount:       sql.NullInt64{Int64: 12, Valid: true},
+					ObservedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					CreatedAt:        time.Date(2024, 1, 2, 3, 5, 0, 0, time.UTC),
+				},
+			},
+		}
+
+		ctx := context.Background()
+
+		var observation TreeObservation
+		mockDb.EXPECT().QueryContext(ctx, `SELECT o.id, o.tree_id, t.x, t.y, o.mission_id, o.health_score, o.suspected_disease, o.pests, o.fruit_count, o.notes, o.observed_at, o.created_at
+		FROM tree_observations o JOIN estate_trees t ON t.id = o.tree_id
+		WHERE t.estate_id = $1 AND ($2 = '' OR o.tree_id = $2) AND ($3 = '' OR o.mission_id = $3)
+		ORDER BY o.observed_at DESC, o.created_at DESC
+	`, input.EstateId, input.TreeId, input.MissionId).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&observation.Id, &observation.TreeId, &observation.X, &observation.Y, &observation.MissionId, &observation.HealthScore, &observation.SuspectedDisease, (*pq.StringArray)(&observation.Pests), &observation.FruitCount, &observation.Notes, &observation.ObservedAt, &observation.CreatedAt).DoAndReturn(func(args ...interface{}) interface{} {
+			exp := expOutput.Observations[0]
+			*(args[0].(*string)) = exp.Id
+			*(args[1].(*string)) = exp.TreeId
+			*(args[2].(*int)) = exp.X
+			*(args[3].(*int)) = exp.Y
+			*(args[4].(*sql.NullString)) = exp.MissionId
+			*(args[5].(*int)) = exp.HealthScore
+			*(args[6].(*sql.NullString)) = exp.SuspectedDisease
+			*(args[7].(*pq.StringArray)) = exp.Pests
+			*(args[8].(*sql.NullInt64)) = exp.FruitCount
+			*(args[9].(*sql.NullString)) = exp.Notes
+			*(args[10].(*time.Time)) = exp.ObservedAt
+			*(args[11].(*time.Time)) = exp.CreatedAt
+
+			return nil
+		})
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetTreeObservations(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetTreeObservationsInput{
+			EstateId: "eeeee-fffff-ggggg-hhhhh",
+			TreeId:   "iiiii-jjjjj-kkkkk-lllll",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT o.id, o.tree_id, t.x, t.y, o.mission_id, o.health_score, o.suspected_disease, o.pests, o.fruit_count, o.notes, o.observed_at, o.created_at
+		FROM tree_observations o JOIN estate_trees t ON t.id = o.tree_id
+		WHERE t.estate_id = $1 AND ($2 = '' OR o.tree_id = $2) AND ($3 = '' OR o.mission_id = $3)
+		ORDER BY o.observed_at DESC, o.created_at DESC
+	`, input.EstateId, input.TreeId, input.MissionId).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errAny)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetTreeObservations(ctx, input)
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetTreeObservationsOutput{}, output)
+	})
+
+	t.Run("Return error when query context errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetTreeObservationsInput{
+			EstateId: "eeeee-fffff-ggggg-hhhhh",
+			TreeId:   "iiiii-jjjjj-kkkkk-lllll",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT o.id, o.tree_id, t.x, t.y, o.mission_id, o.health_score, o.suspected_disease, o.pests, o.fruit_count, o.notes, o.observed_at, o.created_at
+		FROM tree_observations o JOIN estate_trees t ON t.id = o.tree_id
+		WHERE t.estate_id = $1 AND ($2 = '' OR o.tree_id = $2) AND ($3 = '' OR o.mission_id = $3)
+		ORDER BY o.observed_at DESC, o.created_at DESC
+	`, input.EstateId, input.TreeId, input.MissionId).Return(nil, errAny)
+
+		_, err := repo.GetTreeObservations(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestGetLatestTreeObservations(t *testing.T) {
+	t.Run("Return the observations when no error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetLatestTreeObservationsInput{
+			EstateId: "eeeee-fffff-ggggg-hhhhh",
+		}
+
+		expOutput := GetLatestTreeObservationsOutput{
+			Observations: []TreeObservation{
+				{
+					Id:               "aaaaa-bbbbb-ccccc-ddddd",
+					TreeId:           "iiiii-jjjjj-kkkkk-lllll",
+					X:                2,
+					Y:                1,
+					MissionId:        sql.NullString{String: "mmmmm-nnnnn-ooooo-ppppp", Valid: true},
+					HealthScore:      4,
+					SuspectedDisease: sql.NullString{String: "leaf rust", Valid: true},
+					Pests:            []string{"aphid"},
+					FruitCount:       sql.NullInt64{Int64: 12, Valid: true},
+					ObservedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					CreatedAt:        time.Date(2024, 1, 2, 3, 5, 0, 0, time.UTC),
+				},
+			},
+		}
+
+		ctx := context.Background()
+
+		var observation TreeObservation
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, tree_id, x, y, mission_id, health_score, suspected_disease, pests, fruit_count, notes, observed_at, created_at FROM (
+			SELECT DISTINCT ON (o.tree_id) o.id, o.tree_id, t.x, t.y, o.mission_id, o.health_score, o.suspected_disease, o.pests, o.fruit_count, o.notes, o.observed_at, o.created_at
+			FROM tree_observations o JOIN estate_trees t ON t.id = o.tree_id
+			WHERE t.estate_id = $1 AND t.status <> 'felled'
+			ORDER BY o.tree_id, o.observed_at DESC, o.created_at DESC
+		) latest
+		ORDER BY y, x
+	`, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&observation.Id, &observation.TreeId, &observation.X, &observation.Y, &observation.MissionId, &observation.HealthScore, &observation.SuspectedDisease, (*pq.StringArray)(&observation.Pests), &observation.FruitCount, &observation.Notes, &observation.ObservedAt, &observation.CreatedAt).DoAndReturn(func(args ...interface{}) interface{} {
+			exp := expOutput.Observations[0]
+			*(args[0].(*string)) = exp.Id
+			*(args[1].(*string)) = exp.TreeId
+			*(args[2].(*int)) = exp.X
+			*(args[3].(*int)) = exp.Y
+			*(args[4].(*sql.NullString)) = exp.MissionId
+			*(args[5].(*int)) = exp.HealthScore
+			*(args[6].(*sql.NullString)) = exp.SuspectedDisease
+			*(args[7].(*pq.StringArray)) = exp.Pests
+			*(args[8].(*sql.NullInt64)) = exp.FruitCount
+			*(args[9].(*sql.NullString)) = exp.Notes
+			*(args[10].(*time.Time)) = exp.ObservedAt
+			*(args[11].(*time.Time)) = exp.CreatedAt
+
+			return nil
+		})
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetLatestTreeObservations(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetLatestTreeObservationsInput{
+			EstateId: "eeeee-fffff-ggggg-hhhhh",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, tree_id, x, y, mission_id, health_score, suspected_disease, pests, fruit_count, notes, observed_at, created_at FROM (
+			SELECT DISTINCT ON (o.tree_id) o.id, o.tree_id, t.x, t.y, o.mission_id, o.health_score, o.suspected_disease, o.pests, o.fruit_count, o.notes, o.observed_at, o.created_at
+			FROM tree_observations o JOIN estate_trees t ON t.id = o.tree_id
+			WHERE t.estate_id = $1 AND t.status <> 'felled'
+			ORDER BY o.tree_id, o.observed_at DESC, o.created_at DESC
+		) latest
+		ORDER BY y, x
+	`, input.EstateId).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errAny)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetLatestTreeObservations(ctx, input)
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetLatestTreeObservationsOutput{}, output)
+	})
+
+	t.Run("Return error when query context errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := GetLatestTreeObservationsInput{
+			EstateId: "eeeee-fffff-ggggg-hhhhh",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, tree_id, x, y, mission_id, health_score, suspected_disease, pests, fruit_count, notes, observed_at, created_at FROM (
+			SELECT DISTINCT ON (o.tree_id) o.id, o.tree_id, t.x, t.y, o.mission_id, o.health_score, o.suspected_disease, o.pests, o.fruit_count, o.notes, o.observed_at, o.created_at
+			FROM tree_observations o JOIN estate_trees t ON t.id = o.tree_id
+			WHERE t.estate_id = $1 AND t.status <> 'felled'
+			ORDER BY o.tree_id, o.observed_at DESC, o.created_at DESC
+		) latest
+		ORDER BY y, x
+	`, input.EstateId).Return(nil, errAny)
+
+		_, err := repo.GetLatestTreeObservations(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestCreateSpecies(t *testing.T) {
+	t.Run("Return no error when insert is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := CreateSpeciesInput{
+			Species: Species{
+				Id:            "aaaaa-bbbbb-ccccc-ddddd",
+				Name:          "Oil palm",
+				MaxHeight:     20,
+				GrowthProfile: "moderate",
+			},
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO tree_species (id, name, max_height, growth_profile, created_at, updated_at) VALUES ($1, $2, $3, $4, NOW(), NOW()) ON CONFLICT (name) DO NOTHING RETURNING id`, input.Id, input.Name, input.MaxHeight, input.GrowthProfile).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any()).Return(nil)
+
+		err := repo.CreateSpecies(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return no rows error when the name exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := CreateSpeciesInput{
+			Species: Species{
+				Id:   "aaaaa-bbbbb-ccccc-ddddd",
+				Name: "Oil palm",
+			},
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `INSERT INTO tree_species (id, name, max_height, growth_profile, created_at, updated_at) VALUES ($1, $2, $3, $4, NOW(), NOW()) ON CONFLICT (name) DO NOTHING RETURNING id`, input.Id, input.Name, input.MaxHeight, input.GrowthProfile).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+		err := repo.CreateSpecies(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+}
+
+func TestGetSpeciesById(t *testing.T) {
+	t.Run("Return the species when found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetSpeciesByIdInput{
+			Id: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		expOutput := GetSpeciesByIdOutput{
+			Species: Species{Id: input.Id, Name: "Oil palm", MaxHeight: 20, GrowthProfile: "moderate"},
+		}
+
+		ctx := context.Background()
+
+		var output GetSpeciesByIdOutput
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, name, max_height, growth_profile FROM tree_species WHERE id = $1`, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(&output.Id, &output.Name, &output.MaxHeight, &output.GrowthProfile).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*string)) = expOutput.Id
+			*(args[1].(*string)) = expOutput.Name
+			*(args[2].(*int)) = expOutput.MaxHeight
+			*(args[3].(*string)) = expOutput.GrowthProfile
+
+			return nil
+		})
+
+		output, err := repo.GetSpeciesById(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRow := db.NewMockRow(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetSpeciesByIdInput{
+			Id: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryRowContext(ctx, `SELECT id, name, max_height, growth_profile FROM tree_species WHERE id = $1`, input.Id).Return(mockRow)
+		mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+
+		_, err := repo.GetSpeciesById(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+}
+
+func TestGetAllSpecies(t *testing.T) {
+	t.Run("Return the species when no error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		expOutput := GetAllSpeciesOutput{
+			Species: []Species{
+				{Id: "aaaaa-bbbbb-ccccc-ddddd", Name: "Oil palm", MaxHeight: 20, GrowthProfile: "moderate"},
+			},
+		}
+
+		ctx := context.Background()
+
+		var species Species
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, name, max_height, growth_profile FROM tree_species ORDER BY name`).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(&species.Id, &species.Name, &species.MaxHeight, &species.GrowthProfile).DoAndReturn(func(args ...interface{}) interface{} {
+			*(args[0].(*string)) = expOutput.Species[0].Id
+			*(args[1].(*string)) = expOutput.Species[0].Name
+			*(args[2].(*int)) = expOutput.Species[0].MaxHeight
+			*(args[3].(*string)) = expOutput.Species[0].GrowthProfile
+
+			return nil
+		})
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetAllSpecies(ctx, GetAllSpeciesInput{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, name, max_height, growth_profile FROM tree_species ORDER BY name`).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errAny)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetAllSpecies(ctx, GetAllSpeciesInput{})
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetAllSpeciesOutput{}, output)
+	})
+
+	t.Run("Return error when query context errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT id, name, max_height, growth_profile FROM tree_species ORDER BY name`).Return(nil, errAny)
+
+		_, err := repo.GetAllSpecies(ctx, GetAllSpeciesInput{})
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestUpdateTreeStatus(t *testing.T) {
+	t.Run("Return no error when update is success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockTx := db.NewMockTx(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := UpdateTreeStatusInput{
+			Id:       "aaaaa-bbbbb-ccccc-ddddd",
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			From:     "planted",
+			To:       "diseased",
+			Reason:   sql.NullString{String: "root rot", Valid: true},
+
+			DroneDistFactor: -6,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().BeginTx(ctx, &sql.TxOptions{}).Return(mockTx, nil)
+		mockTx.EXPECT().Rollback()
+		mockTx.EXPECT().QueryContext(ctx, `UPDATE estate_trees SET status = $1, updated_at = NOW() WHERE id = $2 AND estate_id = $3 AND status = $4 RETURNING id`, input.To, input.Id, input.EstateId, input.From).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `INSERT INTO tree_status_history (tree_id, from_status, to_status, reason, changed_at) VALUES ($1, $2, $3, $4, NOW())`, input.Id, input.From, input.To, input.Reason).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().Commit().Return(nil)
+
+		err := repo.UpdateTreeStatus(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return no error and update the estate when the tree is felled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockTx := db.NewMockTx(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := UpdateTreeStatusInput{
+			Id:       "aaaaa-bbbbb-ccccc-ddddd",
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			From:     "planted",
+			To:       "felled",
+			Reason:   sql.NullString{String: "root rot", Valid: true},
+
+			DroneDistFactor: -6,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().BeginTx(ctx, &sql.TxOptions{}).Return(mockTx, nil)
+		mockTx.EXPECT().Rollback()
+		mockTx.EXPECT().QueryContext(ctx, `UPDATE estate_trees SET status = $1, updated_at = NOW() WHERE id = $2 AND estate_id = $3 AND status = $4 RETURNING id`, input.To, input.Id, input.EstateId, input.From).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `INSERT INTO tree_status_history (tree_id, from_status, to_status, reason, changed_at) VALUES ($1, $2, $3, $4, NOW())`, input.Id, input.From, input.To, input.Reason).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `UPDATE estates
+			SET count = count - 1,
+				max = COALESCE((SELECT MAX(height) FROM estate_trees WHERE estate_id = $1 AND status <> 'felled'), 0),
+				min = COALESCE((SELECT MIN(height) FROM estate_trees WHERE estate_id = $1 AND status <> 'felled'), 0),
+				drone_distance = drone_distance + $2,
+				median = NULL,
+				tree_version = tree_version + 1,
+				updated_at = NOW()
+			WHERE id = $1
+		`, input.EstateId, input.DroneDistFactor).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().Commit().Return(nil)
+
+		err := repo.UpdateTreeStatus(ctx, input)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Return no rows error when the tree moved since it was read", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockTx := db.NewMockTx(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := UpdateTreeStatusInput{
+			Id:       "aaaaa-bbbbb-ccccc-ddddd",
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			From:     "planted",
+			To:       "mature",
+			Reason:   sql.NullString{String: "root rot", Valid: true},
+
+			DroneDistFactor: -6,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().BeginTx(ctx, &sql.TxOptions{}).Return(mockTx, nil)
+		mockTx.EXPECT().Rollback()
+		mockTx.EXPECT().QueryContext(ctx, `UPDATE estate_trees SET status = $1, updated_at = NOW() WHERE id = $2 AND estate_id = $3 AND status = $4 RETURNING id`, input.To, input.Id, input.EstateId, input.From).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Close()
+
+		err := repo.UpdateTreeStatus(ctx, input)
+
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+
+	t.Run("Return error when query context of update estates errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockTx := db.NewMockTx(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := UpdateTreeStatusInput{
+			Id:       "aaaaa-bbbbb-ccccc-ddddd",
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			From:     "planted",
+			To:       "felled",
+			Reason:   sql.NullString{String: "root rot", Valid: true},
+
+			DroneDistFactor: -6,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().BeginTx(ctx, &sql.TxOptions{}).Return(mockTx, nil)
+		mockTx.EXPECT().Rollback()
+		mockTx.EXPECT().QueryContext(ctx, `UPDATE estate_trees SET status = $1, updated_at = NOW() WHERE id = $2 AND estate_id = $3 AND status = $4 RETURNING id`, input.To, input.Id, input.EstateId, input.From).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `INSERT INTO tree_status_history (tree_id, from_status, to_status, reason, changed_at) VALUES ($1, $2, $3, $4, NOW())`, input.Id, input.From, input.To, input.Reason).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `UPDATE estates
+			SET count = count - 1,
+				max = COALESCE((SELECT MAX(height) FROM estate_trees WHERE estate_id = $1 AND status <> 'felled'), 0),
+				min = COALESCE((SELECT MIN(height) FROM estate_trees WHERE estate_id = $1 AND status <> 'felled'), 0),
+				drone_distance = drone_distance + $2,
+				median = NULL,
+				tree_version = tree_version + 1,
+				updated_at = NOW()
+			WHERE id = $1
+		`, input.EstateId, input.DroneDistFactor).Return(nil, errAny)
+
+		err := repo.UpdateTreeStatus(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+
+	t.Run("Return error when commit errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockTx := db.NewMockTx(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		input := UpdateTreeStatusInput{
+			Id:       "aaaaa-bbbbb-ccccc-ddddd",
+			EstateId: "bbbbb-ccccc-ddddd-eeeee",
+			From:     "planted",
+			To:       "mature",
+			Reason:   sql.NullString{String: "root rot", Valid: true},
+
+			DroneDistFactor: -6,
+		}
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().BeginTx(ctx, &sql.TxOptions{}).Return(mockTx, nil)
+		mockTx.EXPECT().Rollback()
+		mockTx.EXPECT().QueryContext(ctx, `UPDATE estate_trees SET status = $1, updated_at = NOW() WHERE id = $2 AND estate_id = $3 AND status = $4 RETURNING id`, input.To, input.Id, input.EstateId, input.From).Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().QueryContext(ctx, `INSERT INTO tree_status_history (tree_id, from_status, to_status, reason, changed_at) VALUES ($1, $2, $3, $4, NOW())`, input.Id, input.From, input.To, input.Reason).Return(mockRows, nil)
+		mockRows.EXPECT().Close()
+		mockTx.EXPECT().Commit().Return(errAny)
+
+		err := repo.UpdateTreeStatus(ctx, input)
+
+		assert.Equal(t, errAny, err)
+	})
+}
+
+func TestGetTreeStatusHistory(t *testing.T) {
+	t.Run("Return the history when no error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		input := GetTreeStatusHistoryInput{
+			TreeId: "aaaaa-bbbbb-ccccc-ddddd",
+		}
+
+		expOutput := GetTreeStatusHistoryOutput{
+			History: []TreeStatusChange{
+				{To: "planted", ChangedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+				{From: sql.NullString{String: "planted", Valid: true}, To: "felled", Reason: sql.NullString{String: "storm", Valid: true}, ChangedAt: time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)},
+			},
+		}
+
+		ctx := context.Background()
+
+		var change TreeStatusChange
+		mockDb.EXPECT().QueryContext(ctx, `SELECT from_status, to_status, reason, changed_at FROM tree_status_history WHERE tree_id = $1 ORDER BY changed_at, id`, input.TreeId).Return(mockRows, nil)
+		for _, exp := range expOutput.History {
+			exp := exp
+			mockRows.EXPECT().Next().Return(true)
+			mockRows.EXPECT().Scan(&change.From, &change.To, &change.Reason, &change.ChangedAt).DoAndReturn(func(args ...interface{}) interface{} {
+				*(args[0].(*sql.NullString)) = exp.From
+				*(args[1].(*string)) = exp.To
+				*(args[2].(*sql.NullString)) = exp.Reason
+				*(args[3].(*time.Time)) = exp.ChangedAt
+
+				return nil
+			})
+		}
+		mockRows.EXPECT().Next().Return(false)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetTreeStatusHistory(ctx, input)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expOutput, output)
+	})
+
+	t.Run("Return error when scan errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDb := db.NewMockDB(ctrl)
+		mockRows := db.NewMockRows(ctrl)
+
+		repo := Repository{
+			Db: mockDb,
+		}
+
+		errAny := errors.New("any error")
+
+		ctx := context.Background()
+
+		mockDb.EXPECT().QueryContext(ctx, `SELECT from_status, to_status, reason, changed_at FROM tree_status_history WHERE tree_id = $1 ORDER BY changed_at, id`, "aaaaa-bbbbb-ccccc-ddddd").Return(mockRows, nil)
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errAny)
+		mockRows.EXPECT().Close()
+
+		output, err := repo.GetTreeStatusHistory(ctx, GetTreeStatusHistoryInput{TreeId: "aaaaa-bbbbb-ccccc-ddddd"})
+
+		assert.Equal(t, errAny, err)
+		assert.Equal(t, GetTreeStatusHistoryOutput{}, output)
+	})
+}
@@ -14,4 +14,35 @@ type RepositoryInterface interface {
 	CreateTree(ctx context.Context, input CreateTreeInput) (err error)
 	GetHeightEstateTrees(ctx context.Context, input GetHeightEstateTreesInput) (output GetHeightEstateTreesOutput, err error)
 	StoreMedianEstate(ctx context.Context, input StoreMedianEstateInput) (err error)
+	GetEstateTrees(ctx context.Context, input GetEstateTreesInput) (output GetEstateTreesOutput, err error)
+	CreateObstacle(ctx context.Context, input CreateObstacleInput) (err error)
+	GetEstateObstacles(ctx context.Context, input GetEstateObstaclesInput) (output GetEstateObstaclesOutput, err error)
+	DeleteObstacle(ctx context.Context, input DeleteObstacleInput) (err error)
+	GetEstateElevations(ctx context.Context, input GetEstateElevationsInput) (output GetEstateElevationsOutput, err error)
+	StoreEstateElevations(ctx context.Context, input StoreEstateElevationsInput) (err error)
+	StoreEstateHome(ctx context.Context, input StoreEstateHomeInput) (err error)
+	StoreEstateCeiling(ctx context.Context, input StoreEstateCeilingInput) (err error)
+	CreateDrone(ctx context.Context, input CreateDroneInput) (err error)
+	GetDroneById(ctx context.Context, input GetDroneByIdInput) (output GetDroneByIdOutput, err error)
+	GetDrones(ctx context.Context, input GetDronesInput) (output GetDronesOutput, err error)
+	UpdateDrone(ctx context.Context, input UpdateDroneInput) (err error)
+	DeleteDrone(ctx context.Context, input DeleteDroneInput) (err error)
+	CreateDronePlanSnapshot(ctx context.Context, input CreateDronePlanSnapshotInput) (output CreateDronePlanSnapshotOutput, err error)
+	GetDronePlanSnapshot(ctx context.Context, input GetDronePlanSnapshotInput) (output GetDronePlanSnapshotOutput, err error)
+	CreateMission(ctx context.Context, input CreateMissionInput) (output CreateMissionOutput, err error)
+	GetMissionById(ctx context.Context, input GetMissionByIdInput) (output GetMissionByIdOutput, err error)
+	GetMissions(ctx context.Context, input GetMissionsInput) (output GetMissionsOutput, err error)
+	UpdateMissionDrone(ctx context.Context, input UpdateMissionDroneInput) (err error)
+	UpdateMissionStatus(ctx context.Context, input UpdateMissionStatusInput) (output UpdateMissionStatusOutput, err error)
+	StoreMissionTelemetry(ctx context.Context, input StoreMissionTelemetryInput) (output StoreMissionTelemetryOutput, err error)
+	GetMissionTelemetry(ctx context.Context, input GetMissionTelemetryInput) (output GetMissionTelemetryOutput, err error)
+	GetTreeById(ctx context.Context, input GetTreeByIdInput) (output GetTreeByIdOutput, err error)
+	UpdateTreeStatus(ctx context.Context, input UpdateTreeStatusInput) (err error)
+	GetTreeStatusHistory(ctx context.Context, input GetTreeStatusHistoryInput) (output GetTreeStatusHistoryOutput, err error)
+	CreateTreeObservation(ctx context.Context, input CreateTreeObservationInput) (err error)
+	GetTreeObservations(ctx context.Context, input GetTreeObservationsInput) (output GetTreeObservationsOutput, err error)
+	GetLatestTreeObservations(ctx context.Context, input GetLatestTreeObservationsInput) (output GetLatestTreeObservationsOutput, err error)
+	CreateSpecies(ctx context.Context, input CreateSpeciesInput) (err error)
+	GetSpeciesById(ctx context.Context, input GetSpeciesByIdInput) (output GetSpeciesByIdOutput, err error)
+	GetAllSpecies(ctx context.Context, input GetAllSpeciesInput) (output GetAllSpeciesOutput, err error)
 }
@@ -49,6 +49,35 @@ func (mr *MockRepositoryInterfaceMockRecorder) CountCoordinateTree(ctx, input in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCoordinateTree", reflect.TypeOf((*MockRepositoryInterface)(nil).CountCoordinateTree), ctx, input)
 }
 
+// CreateDrone mocks base method.
+func (m *MockRepositoryInterface) CreateDrone(ctx context.Context, input CreateDroneInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDrone", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDrone indicates an expected call of CreateDrone.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateDrone(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDrone", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateDrone), ctx, input)
+}
+
+// CreateDronePlanSnapshot mocks base method.
+func (m *MockRepositoryInterface) CreateDronePlanSnapshot(ctx context.Context, input CreateDronePlanSnapshotInput) (CreateDronePlanSnapshotOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDronePlanSnapshot", ctx, input)
+	ret0, _ := ret[0].(CreateDronePlanSnapshotOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDronePlanSnapshot indicates an expected call of CreateDronePlanSnapshot.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateDronePlanSnapshot(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDronePlanSnapshot", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateDronePlanSnapshot), ctx, input)
+}
+
 // CreateEstate mocks base method.
 func (m *MockRepositoryInterface) CreateEstate(ctx context.Context, input CreateEstateInput) error {
 	m.ctrl.T.Helper()
@@ -63,6 +92,49 @@ func (mr *MockRepositoryInterfaceMockRecorder) CreateEstate(ctx, input interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEstate", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateEstate), ctx, input)
 }
 
+// CreateMission mocks base method.
+func (m *MockRepositoryInterface) CreateMission(ctx context.Context, input CreateMissionInput) (CreateMissionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMission", ctx, input)
+	ret0, _ := ret[0].(CreateMissionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMission indicates an expected call of CreateMission.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateMission(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMission", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateMission), ctx, input)
+}
+
+// CreateObstacle mocks base method.
+func (m *MockRepositoryInterface) CreateObstacle(ctx context.Context, input CreateObstacleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateObstacle", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateObstacle indicates an expected call of CreateObstacle.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateObstacle(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateObstacle", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateObstacle), ctx, input)
+}
+
+// CreateSpecies mocks base method.
+func (m *MockRepositoryInterface) CreateSpecies(ctx context.Context, input CreateSpeciesInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSpecies", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSpecies indicates an expected call of CreateSpecies.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateSpecies(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSpecies", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateSpecies), ctx, input)
+}
+
 // CreateTree mocks base method.
 func (m *MockRepositoryInterface) CreateTree(ctx context.Context, input CreateTreeInput) error {
 	m.ctrl.T.Helper()
@@ -77,6 +149,108 @@ func (mr *MockRepositoryInterfaceMockRecorder) CreateTree(ctx, input interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTree", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateTree), ctx, input)
 }
 
+// CreateTreeObservation mocks base method.
+func (m *MockRepositoryInterface) CreateTreeObservation(ctx context.Context, input CreateTreeObservationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTreeObservation", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTreeObservation indicates an expected call of CreateTreeObservation.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateTreeObservation(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTreeObservation", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateTreeObservation), ctx, input)
+}
+
+// DeleteDrone mocks base method.
+func (m *MockRepositoryInterface) DeleteDrone(ctx context.Context, input DeleteDroneInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDrone", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDrone indicates an expected call of DeleteDrone.
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteDrone(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDrone", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteDrone), ctx, input)
+}
+
+// DeleteObstacle mocks base method.
+func (m *MockRepositoryInterface) DeleteObstacle(ctx context.Context, input DeleteObstacleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteObstacle", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteObstacle indicates an expected call of DeleteObstacle.
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteObstacle(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObstacle", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteObstacle), ctx, input)
+}
+
+// GetAllSpecies mocks base method.
+func (m *MockRepositoryInterface) GetAllSpecies(ctx context.Context, input GetAllSpeciesInput) (GetAllSpeciesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSpecies", ctx, input)
+	ret0, _ := ret[0].(GetAllSpeciesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSpecies indicates an expected call of GetAllSpecies.
+func (mr *MockRepositoryInterfaceMockRecorder) GetAllSpecies(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSpecies", reflect.TypeOf((*MockRepositoryInterface)(nil).GetAllSpecies), ctx, input)
+}
+
+// GetDroneById mocks base method.
+func (m *MockRepositoryInterface) GetDroneById(ctx context.Context, input GetDroneByIdInput) (GetDroneByIdOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDroneById", ctx, input)
+	ret0, _ := ret[0].(GetDroneByIdOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDroneById indicates an expected call of GetDroneById.
+func (mr *MockRepositoryInterfaceMockRecorder) GetDroneById(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDroneById", reflect.TypeOf((*MockRepositoryInterface)(nil).GetDroneById), ctx, input)
+}
+
+// GetDronePlanSnapshot mocks base method.
+func (m *MockRepositoryInterface) GetDronePlanSnapshot(ctx context.Context, input GetDronePlanSnapshotInput) (GetDronePlanSnapshotOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDronePlanSnapshot", ctx, input)
+	ret0, _ := ret[0].(GetDronePlanSnapshotOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDronePlanSnapshot indicates an expected call of GetDronePlanSnapshot.
+func (mr *MockRepositoryInterfaceMockRecorder) GetDronePlanSnapshot(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDronePlanSnapshot", reflect.TypeOf((*MockRepositoryInterface)(nil).GetDronePlanSnapshot), ctx, input)
+}
+
+// GetDrones mocks base method.
+func (m *MockRepositoryInterface) GetDrones(ctx context.Context, input GetDronesInput) (GetDronesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDrones", ctx, input)
+	ret0, _ := ret[0].(GetDronesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDrones indicates an expected call of GetDrones.
+func (mr *MockRepositoryInterfaceMockRecorder) GetDrones(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrones", reflect.TypeOf((*MockRepositoryInterface)(nil).GetDrones), ctx, input)
+}
+
 // GetEstateById mocks base method.
 func (m *MockRepositoryInterface) GetEstateById(ctx context.Context, input GetEstateByIdInput) (GetEstateByIdOutput, error) {
 	m.ctrl.T.Helper()
@@ -92,6 +266,51 @@ func (mr *MockRepositoryInterfaceMockRecorder) GetEstateById(ctx, input interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateById", reflect.TypeOf((*MockRepositoryInterface)(nil).GetEstateById), ctx, input)
 }
 
+// GetEstateElevations mocks base method.
+func (m *MockRepositoryInterface) GetEstateElevations(ctx context.Context, input GetEstateElevationsInput) (GetEstateElevationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateElevations", ctx, input)
+	ret0, _ := ret[0].(GetEstateElevationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEstateElevations indicates an expected call of GetEstateElevations.
+func (mr *MockRepositoryInterfaceMockRecorder) GetEstateElevations(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateElevations", reflect.TypeOf((*MockRepositoryInterface)(nil).GetEstateElevations), ctx, input)
+}
+
+// GetEstateObstacles mocks base method.
+func (m *MockRepositoryInterface) GetEstateObstacles(ctx context.Context, input GetEstateObstaclesInput) (GetEstateObstaclesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateObstacles", ctx, input)
+	ret0, _ := ret[0].(GetEstateObstaclesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEstateObstacles indicates an expected call of GetEstateObstacles.
+func (mr *MockRepositoryInterfaceMockRecorder) GetEstateObstacles(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateObstacles", reflect.TypeOf((*MockRepositoryInterface)(nil).GetEstateObstacles), ctx, input)
+}
+
+// GetEstateTrees mocks base method.
+func (m *MockRepositoryInterface) GetEstateTrees(ctx context.Context, input GetEstateTreesInput) (GetEstateTreesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateTrees", ctx, input)
+	ret0, _ := ret[0].(GetEstateTreesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEstateTrees indicates an expected call of GetEstateTrees.
+func (mr *MockRepositoryInterfaceMockRecorder) GetEstateTrees(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateTrees", reflect.TypeOf((*MockRepositoryInterface)(nil).GetEstateTrees), ctx, input)
+}
+
 // GetHeightEstateTrees mocks base method.
 func (m *MockRepositoryInterface) GetHeightEstateTrees(ctx context.Context, input GetHeightEstateTreesInput) (GetHeightEstateTreesOutput, error) {
 	m.ctrl.T.Helper()
@@ -107,6 +326,66 @@ func (mr *MockRepositoryInterfaceMockRecorder) GetHeightEstateTrees(ctx, input i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeightEstateTrees", reflect.TypeOf((*MockRepositoryInterface)(nil).GetHeightEstateTrees), ctx, input)
 }
 
+// GetLatestTreeObservations mocks base method.
+func (m *MockRepositoryInterface) GetLatestTreeObservations(ctx context.Context, input GetLatestTreeObservationsInput) (GetLatestTreeObservationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestTreeObservations", ctx, input)
+	ret0, _ := ret[0].(GetLatestTreeObservationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestTreeObservations indicates an expected call of GetLatestTreeObservations.
+func (mr *MockRepositoryInterfaceMockRecorder) GetLatestTreeObservations(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestTreeObservations", reflect.TypeOf((*MockRepositoryInterface)(nil).GetLatestTreeObservations), ctx, input)
+}
+
+// GetMissionById mocks base method.
+func (m *MockRepositoryInterface) GetMissionById(ctx context.Context, input GetMissionByIdInput) (GetMissionByIdOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissionById", ctx, input)
+	ret0, _ := ret[0].(GetMissionByIdOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissionById indicates an expected call of GetMissionById.
+func (mr *MockRepositoryInterfaceMockRecorder) GetMissionById(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissionById", reflect.TypeOf((*MockRepositoryInterface)(nil).GetMissionById), ctx, input)
+}
+
+// GetMissionTelemetry mocks base method.
+func (m *MockRepositoryInterface) GetMissionTelemetry(ctx context.Context, input GetMissionTelemetryInput) (GetMissionTelemetryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissionTelemetry", ctx, input)
+	ret0, _ := ret[0].(GetMissionTelemetryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissionTelemetry indicates an expected call of GetMissionTelemetry.
+func (mr *MockRepositoryInterfaceMockRecorder) GetMissionTelemetry(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissionTelemetry", reflect.TypeOf((*MockRepositoryInterface)(nil).GetMissionTelemetry), ctx, input)
+}
+
+// GetMissions mocks base method.
+func (m *MockRepositoryInterface) GetMissions(ctx context.Context, input GetMissionsInput) (GetMissionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissions", ctx, input)
+	ret0, _ := ret[0].(GetMissionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissions indicates an expected call of GetMissions.
+func (mr *MockRepositoryInterfaceMockRecorder) GetMissions(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissions", reflect.TypeOf((*MockRepositoryInterface)(nil).GetMissions), ctx, input)
+}
+
 // GetPrevNextTree mocks base method.
 func (m *MockRepositoryInterface) GetPrevNextTree(ctx context.Context, input GetPrevNextTreeInput) (GetPrevNextTreeOutput, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +401,108 @@ func (mr *MockRepositoryInterfaceMockRecorder) GetPrevNextTree(ctx, input interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrevNextTree", reflect.TypeOf((*MockRepositoryInterface)(nil).GetPrevNextTree), ctx, input)
 }
 
+// GetSpeciesById mocks base method.
+func (m *MockRepositoryInterface) GetSpeciesById(ctx context.Context, input GetSpeciesByIdInput) (GetSpeciesByIdOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpeciesById", ctx, input)
+	ret0, _ := ret[0].(GetSpeciesByIdOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpeciesById indicates an expected call of GetSpeciesById.
+func (mr *MockRepositoryInterfaceMockRecorder) GetSpeciesById(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpeciesById", reflect.TypeOf((*MockRepositoryInterface)(nil).GetSpeciesById), ctx, input)
+}
+
+// GetTreeById mocks base method.
+func (m *MockRepositoryInterface) GetTreeById(ctx context.Context, input GetTreeByIdInput) (GetTreeByIdOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeById", ctx, input)
+	ret0, _ := ret[0].(GetTreeByIdOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeById indicates an expected call of GetTreeById.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeById(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeById", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeById), ctx, input)
+}
+
+// GetTreeObservations mocks base method.
+func (m *MockRepositoryInterface) GetTreeObservations(ctx context.Context, input GetTreeObservationsInput) (GetTreeObservationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeObservations", ctx, input)
+	ret0, _ := ret[0].(GetTreeObservationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeObservations indicates an expected call of GetTreeObservations.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeObservations(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeObservations", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeObservations), ctx, input)
+}
+
+// GetTreeStatusHistory mocks base method.
+func (m *MockRepositoryInterface) GetTreeStatusHistory(ctx context.Context, input GetTreeStatusHistoryInput) (GetTreeStatusHistoryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeStatusHistory", ctx, input)
+	ret0, _ := ret[0].(GetTreeStatusHistoryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeStatusHistory indicates an expected call of GetTreeStatusHistory.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeStatusHistory(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeStatusHistory", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeStatusHistory), ctx, input)
+}
+
+// StoreEstateCeiling mocks base method.
+func (m *MockRepositoryInterface) StoreEstateCeiling(ctx context.Context, input StoreEstateCeilingInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreEstateCeiling", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreEstateCeiling indicates an expected call of StoreEstateCeiling.
+func (mr *MockRepositoryInterfaceMockRecorder) StoreEstateCeiling(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreEstateCeiling", reflect.TypeOf((*MockRepositoryInterface)(nil).StoreEstateCeiling), ctx, input)
+}
+
+// StoreEstateElevations mocks base method.
+func (m *MockRepositoryInterface) StoreEstateElevations(ctx context.Context, input StoreEstateElevationsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreEstateElevations", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreEstateElevations indicates an expected call of StoreEstateElevations.
+func (mr *MockRepositoryInterfaceMockRecorder) StoreEstateElevations(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreEstateElevations", reflect.TypeOf((*MockRepositoryInterface)(nil).StoreEstateElevations), ctx, input)
+}
+
+// StoreEstateHome mocks base method.
+func (m *MockRepositoryInterface) StoreEstateHome(ctx context.Context, input StoreEstateHomeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreEstateHome", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreEstateHome indicates an expected call of StoreEstateHome.
+func (mr *MockRepositoryInterfaceMockRecorder) StoreEstateHome(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreEstateHome", reflect.TypeOf((*MockRepositoryInterface)(nil).StoreEstateHome), ctx, input)
+}
+
 // StoreMedianEstate mocks base method.
 func (m *MockRepositoryInterface) StoreMedianEstate(ctx context.Context, input StoreMedianEstateInput) error {
 	m.ctrl.T.Helper()
@@ -135,3 +516,75 @@ func (mr *MockRepositoryInterfaceMockRecorder) StoreMedianEstate(ctx, input inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreMedianEstate", reflect.TypeOf((*MockRepositoryInterface)(nil).StoreMedianEstate), ctx, input)
 }
+
+// StoreMissionTelemetry mocks base method.
+func (m *MockRepositoryInterface) StoreMissionTelemetry(ctx context.Context, input StoreMissionTelemetryInput) (StoreMissionTelemetryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreMissionTelemetry", ctx, input)
+	ret0, _ := ret[0].(StoreMissionTelemetryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreMissionTelemetry indicates an expected call of StoreMissionTelemetry.
+func (mr *MockRepositoryInterfaceMockRecorder) StoreMissionTelemetry(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreMissionTelemetry", reflect.TypeOf((*MockRepositoryInterface)(nil).StoreMissionTelemetry), ctx, input)
+}
+
+// UpdateDrone mocks base method.
+func (m *MockRepositoryInterface) UpdateDrone(ctx context.Context, input UpdateDroneInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDrone", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDrone indicates an expected call of UpdateDrone.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateDrone(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDrone", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateDrone), ctx, input)
+}
+
+// UpdateMissionDrone mocks base method.
+func (m *MockRepositoryInterface) UpdateMissionDrone(ctx context.Context, input UpdateMissionDroneInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMissionDrone", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMissionDrone indicates an expected call of UpdateMissionDrone.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateMissionDrone(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMissionDrone", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateMissionDrone), ctx, input)
+}
+
+// UpdateMissionStatus mocks base method.
+func (m *MockRepositoryInterface) UpdateMissionStatus(ctx context.Context, input UpdateMissionStatusInput) (UpdateMissionStatusOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMissionStatus", ctx, input)
+	ret0, _ := ret[0].(UpdateMissionStatusOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMissionStatus indicates an expected call of UpdateMissionStatus.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateMissionStatus(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMissionStatus", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateMissionStatus), ctx, input)
+}
+
+// UpdateTreeStatus mocks base method.
+func (m *MockRepositoryInterface) UpdateTreeStatus(ctx context.Context, input UpdateTreeStatusInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTreeStatus", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTreeStatus indicates an expected call of UpdateTreeStatus.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateTreeStatus(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTreeStatus", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateTreeStatus), ctx, input)
+}
@@ -1,6 +1,11 @@
 // This file contains types that are used in the repository layer.
 package repository
 
+import (
+	"database/sql"
+	"time"
+)
+
 type GetTestByIdInput struct {
 	Id string
 }
@@ -13,6 +18,14 @@ type CreateEstateInput struct {
 	Id     string
 	Width  int
 	Length int
+
+	// Ceiling is the highest the drone may fly above the ground, in meters
+	Ceiling int
+
+	// Elevations are the plots above ground level 0, and ElevationDistance is
+	// what the drone climbs and descends following them along the path
+	Elevations        []PlotElevation
+	ElevationDistance int
 }
 
 type GetEstateByIdInput struct {
@@ -26,13 +39,23 @@ type GetEstateByIdOutput struct {
 	Count         int
 	Max           int
 	Min           int
-	Median        float64
+	Median        sql.NullFloat64
 	DroneDistance int
+	Ceiling       int
+
+	// TreeVersion goes up every time a tree is planted or felled
+	TreeVersion int
+
+	// HomeX and HomeY are the plot the drone takes off from and lands back
+	// on, null when the estate has no home
+	HomeX sql.NullInt64
+	HomeY sql.NullInt64
 }
 
 type CountCoordinateTreeInput struct {
-	X int
-	Y int
+	EstateId string
+	X        int
+	Y        int
 }
 
 type CountCoordinateTreeOutput struct {
@@ -40,19 +63,25 @@ type CountCoordinateTreeOutput struct {
 }
 
 type CreateTreeInput struct {
-	Id     string
-	X      int
-	Y      int
-	Height int
+	Id        string
+	X         int
+	Y         int
+	Height    int
+	SpeciesId sql.NullString
 
 	EstateId        string
 	DroneDistFactor int
 }
 
 type GetPrevNextTreeInput struct {
+	EstateId string
+
 	PrevX int
 	PrevY int
 
+	X int
+	Y int
+
 	NextX int
 	NextY int
 }
@@ -60,6 +89,10 @@ type GetPrevNextTreeInput struct {
 type GetPrevNextTreeOutput struct {
 	PrevTreeHeight int
 	NextTreeHeight int
+
+	PrevElevation int
+	Elevation     int
+	NextElevation int
 }
 
 type GetHeightEstateTreesInput struct {
@@ -73,4 +106,378 @@ type GetHeightEstateTreesOutput struct {
 type StoreMedianEstateInput struct {
 	EstateId string
 	Median   float64
+
+	// TreeVersion is the tree version of the estate the median was computed
+	// from. The median is only stored when no tree was planted or felled since.
+	TreeVersion int
+}
+
+type EstateTree struct {
+	Id        string
+	X         int
+	Y         int
+	Height    int
+	SpeciesId sql.NullString
+	Status    string
+}
+
+type GetEstateTreesInput struct {
+	EstateId string
+
+	MinX int
+	MaxX int
+	MinY int
+	MaxY int
+}
+
+type GetEstateTreesOutput struct {
+	Trees []EstateTree
+}
+
+type EstateObstacle struct {
+	Id          string
+	X           int
+	Y           int
+	Height      int
+	NoFly       bool
+	Description string
+}
+
+type CreateObstacleInput struct {
+	EstateObstacle
+
+	EstateId string
+}
+
+type GetEstateObstaclesInput struct {
+	EstateId string
+}
+
+type GetEstateObstaclesOutput struct {
+	Obstacles []EstateObstacle
+}
+
+type DeleteObstacleInput struct {
+	Id       string
+	EstateId string
+}
+
+type PlotElevation struct {
+	X         int
+	Y         int
+	Elevation int
+}
+
+type GetEstateElevationsInput struct {
+	EstateId string
+}
+
+type GetEstateElevationsOutput struct {
+	Elevations []PlotElevation
+}
+
+type StoreEstateElevationsInput struct {
+	EstateId        string
+	Elevations      []PlotElevation
+	DroneDistFactor int
+}
+
+type StoreEstateCeilingInput struct {
+	EstateId string
+	Ceiling  int
+}
+
+type StoreEstateHomeInput struct {
+	EstateId string
+	X        int
+	Y        int
+}
+
+// Species is a tree species of the catalogue. GrowthProfile is slow,
+// moderate or fast, and MaxHeight the tallest a tree of it grows, in meters.
+type Species struct {
+	Id            string
+	Name          string
+	MaxHeight     int
+	GrowthProfile string
+}
+
+type CreateSpeciesInput struct {
+	Species
+}
+
+type GetSpeciesByIdInput struct {
+	Id string
+}
+
+type GetSpeciesByIdOutput struct {
+	Species
+}
+
+type GetAllSpeciesInput struct{}
+
+type GetAllSpeciesOutput struct {
+	Species []Species
+}
+
+type Drone struct {
+	Id    string
+	Model string
+
+	// MaxRange and MaxAltitude are in meters, CruiseSpeed and ClimbRate in
+	// meters per second, and BatteryCapacity in watt-hours
+	MaxRange        int
+	MaxAltitude     int
+	CruiseSpeed     float64
+	ClimbRate       float64
+	BatteryCapacity float64
+}
+
+type CreateDroneInput struct {
+	Drone
+}
+
+type GetDroneByIdInput struct {
+	Id string
+}
+
+type GetDroneByIdOutput struct {
+	Drone
+}
+
+type GetDronesInput struct{}
+
+type GetDronesOutput struct {
+	Drones []Drone
+}
+
+type UpdateDroneInput struct {
+	Drone
+}
+
+type DeleteDroneInput struct {
+	Id string
+}
+
+// DronePlanSnapshot is a drone plan frozen with the waypoints it was computed
+// with. TreeHash fingerprints the estate trees at that time, so the plan is
+// stale once the trees hash differently.
+type DronePlanSnapshot struct {
+	Id         string
+	EstateId   string
+	Version    int
+	Mode       string
+	TreeHash   string
+	Distance   int
+	FlightTime float64
+	Energy     float64
+	Waypoints  []PlanWaypoint
+	CreatedAt  time.Time
+}
+
+// PlanWaypoint is stored as JSON within the snapshot
+type PlanWaypoint struct {
+	X        int  `json:"x"`
+	Y        int  `json:"y"`
+	Altitude int  `json:"altitude"`
+	Transit  bool `json:"transit,omitempty"`
+}
+
+type CreateDronePlanSnapshotInput struct {
+	DronePlanSnapshot
+}
+
+type CreateDronePlanSnapshotOutput struct {
+	Version   int
+	CreatedAt time.Time
+}
+
+// GetDronePlanSnapshotInput gets the latest version when Version is 0
+type GetDronePlanSnapshotInput struct {
+	EstateId string
+	Version  int
+}
+
+type GetDronePlanSnapshotOutput struct {
+	DronePlanSnapshot
+}
+
+// Mission flies a snapshot of the estate drone plan. StartedAt is set once it
+// is in progress, and EndedAt once it is completed or aborted.
+type Mission struct {
+	Id              string
+	EstateId        string
+	SnapshotId      string
+	SnapshotVersion int
+	DroneId         sql.NullString
+	Status          string
+	AbortReason     sql.NullString
+	CreatedAt       time.Time
+	StartedAt       sql.NullTime
+	EndedAt         sql.NullTime
+}
+
+type CreateMissionInput struct {
+	Mission
+}
+
+type CreateMissionOutput struct {
+	CreatedAt time.Time
+}
+
+type GetMissionByIdInput struct {
+	Id string
+}
+
+type GetMissionByIdOutput struct {
+	Mission
+}
+
+// GetMissionsInput filters the missions by estate and status when given
+type GetMissionsInput struct {
+	EstateId string
+	Status   string
+}
+
+type GetMissionsOutput struct {
+	Missions []Mission
+}
+
+type UpdateMissionDroneInput struct {
+	Id      string
+	DroneId string
+}
+
+// UpdateMissionStatusInput moves the mission from the From status only, so
+// a mission moved in the meantime is not found
+type UpdateMissionStatusInput struct {
+	Id          string
+	From        string
+	To          string
+	AbortReason sql.NullString
+}
+
+type UpdateMissionStatusOutput struct {
+	StartedAt sql.NullTime
+	EndedAt   sql.NullTime
+}
+
+// TelemetryPoint is where the drone reported itself at a time, over the plot
+// at an altitude from the same datum as the terrain elevation, with the
+// battery left in percent
+type TelemetryPoint struct {
+	RecordedAt time.Time
+	X          int
+	Y          int
+	Altitude   float64
+	Battery    float64
+}
+
+type StoreMissionTelemetryInput struct {
+	MissionId string
+	Points    []TelemetryPoint
+}
+
+// StoreMissionTelemetryOutput counts the points stored, leaving out the ones
+// already stored at the same time
+type StoreMissionTelemetryOutput struct {
+	Stored int
+}
+
+type GetMissionTelemetryInput struct {
+	MissionId string
+}
+
+type GetMissionTelemetryOutput struct {
+	Points []TelemetryPoint
+}
+
+type GetTreeByIdInput struct {
+	Id       string
+	EstateId string
+}
+
+type GetTreeByIdOutput struct {
+	EstateTree
+}
+
+// UpdateTreeStatusInput moves the tree from the status it was read with.
+// DroneDistFactor is added to the drone distance of the estate when the tree
+// is felled.
+type UpdateTreeStatusInput struct {
+	Id       string
+	EstateId string
+	From     string
+	To       string
+	Reason   sql.NullString
+
+	DroneDistFactor int
+}
+
+// TreeStatusChange is a move of a tree from one status to another. From is
+// null for the planting of the tree.
+type TreeStatusChange struct {
+	From      sql.NullString
+	To        string
+	Reason    sql.NullString
+	ChangedAt time.Time
+}
+
+type GetTreeStatusHistoryInput struct {
+	TreeId string
+}
+
+type GetTreeStatusHistoryOutput struct {
+	History []TreeStatusChange
+}
+
+// TreeObservation is what the crew tagged on a tree, during a mission or
+// not. X and Y are the plot of the tree.
+type TreeObservation struct {
+	Id               string
+	TreeId           string
+	X                int
+	Y                int
+	MissionId        sql.NullString
+	HealthScore      int
+	SuspectedDisease sql.NullString
+	Pests            []string
+	FruitCount       sql.NullInt64
+	Notes            sql.NullString
+	ObservedAt       time.Time
+	CreatedAt        time.Time
+}
+
+// CreateTreeObservationInput observes the tree now when ObservedAt is not
+// given
+type CreateTreeObservationInput struct {
+	Id               string
+	TreeId           string
+	MissionId        sql.NullString
+	HealthScore      int
+	SuspectedDisease sql.NullString
+	Pests            []string
+	FruitCount       sql.NullInt64
+	Notes            sql.NullString
+	ObservedAt       sql.NullTime
+}
+
+// GetTreeObservationsInput lists every observation of the estate, or only
+// the ones of a tree or a mission when they are given
+type GetTreeObservationsInput struct {
+	EstateId  string
+	TreeId    string
+	MissionId string
+}
+
+type GetTreeObservationsOutput struct {
+	Observations []TreeObservation
+}
+
+type GetLatestTreeObservationsInput struct {
+	EstateId string
+}
+
+type GetLatestTreeObservationsOutput struct {
+	Observations []TreeObservation
 }
@@ -0,0 +1,90 @@
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/naufalfmm/plantation-drone-api/generated"
+)
+
+// Client calls the API the same way a drone in the field would
+type Client struct {
+	BaseUrl string
+	Http    *http.Client
+}
+
+func NewClient(baseUrl string) *Client {
+	return &Client{
+		BaseUrl: strings.TrimRight(baseUrl, "/"),
+		Http:    http.DefaultClient,
+	}
+}
+
+func (c *Client) GetMission(ctx context.Context, id string) (mission generated.MissionResponse, err error) {
+	err = c.do(ctx, http.MethodGet, "/mission/"+url.PathEscape(id), nil, &mission)
+	return mission, err
+}
+
+func (c *Client) GetSnapshot(ctx context.Context, estateId string, version int) (snapshot generated.DronePlanSnapshotResponse, err error) {
+	path := fmt.Sprintf("/estate/%s/drone-plan:snapshot?version=%d", url.PathEscape(estateId), version)
+	err = c.do(ctx, http.MethodGet, path, nil, &snapshot)
+	return snapshot, err
+}
+
+func (c *Client) SetMissionStatus(ctx context.Context, id, status string, reason *string) (mission generated.MissionResponse, err error) {
+	req := generated.MissionStatusRequest{
+		Status: status,
+		Reason: reason,
+	}
+
+	err = c.do(ctx, http.MethodPut, "/mission/"+url.PathEscape(id)+"/status", req, &mission)
+	return mission, err
+}
+
+func (c *Client) PostTelemetry(ctx context.Context, id string, points []generated.TelemetryPointRequest) (resp generated.TelemetryBatchResponse, err error) {
+	req := generated.TelemetryBatchRequest{
+		Points: points,
+	}
+
+	err = c.do(ctx, http.MethodPost, "/mission/"+url.PathEscape(id)+"/telemetry", req, &resp)
+	return resp, err
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseUrl+path, &reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.Http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		var errResp generated.ErrorResponse
+		if err := json.NewDecoder(res.Body).Decode(&errResp); err != nil || errResp.Message == "" {
+			return fmt.Errorf("%s %s: %s", method, path, res.Status)
+		}
+
+		return fmt.Errorf("%s %s: %s: %s", method, path, res.Status, errResp.Message)
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,175 @@
+// Package simulator flies a mission plan without a drone and reports the
+// synthetic telemetry to the API, so the mission pipeline can be exercised
+// without any hardware.
+package simulator
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/naufalfmm/plantation-drone-api/generated"
+)
+
+// plotDistance is the distance between the centres of two neighbouring plots,
+// in metres
+const plotDistance = 10
+
+const (
+	AbortReasonInjected = "simulated abort"
+	AbortReasonBattery  = "battery depleted"
+)
+
+type Config struct {
+	// Speed is how fast the drone flies, in metres per second
+	Speed float64
+
+	// BatteryPerMeter is the battery the drone uses for every metre flown, in
+	// percent
+	BatteryPerMeter float64
+
+	Faults Faults
+
+	// Seed makes the drift of a run repeatable
+	Seed int64
+}
+
+// Faults are injected into the flight. The zero value flies the plan as it
+// is.
+type Faults struct {
+	// BatteryDrain multiplies the battery used for every metre, so 1.5 drains
+	// the battery half again as fast. The drone aborts once its battery runs
+	// out.
+	BatteryDrain float64
+
+	// GPSDrift is the chance of every point being reported over a neighbouring
+	// plot of the plan rather than the plot the drone is above
+	GPSDrift float64
+
+	// AbortAfter aborts the flight once this share of the plan distance is
+	// flown, from 0 to 1. Zero flies the whole plan.
+	AbortAfter float64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Speed:           5,
+		BatteryPerMeter: 0.02,
+		Seed:            time.Now().UnixNano(),
+	}
+}
+
+type Flight struct {
+	Points []generated.TelemetryPointRequest
+
+	// AbortReason is empty when the drone flew the whole plan
+	AbortReason string
+}
+
+// Fly reports a point every time the drone reaches a waypoint of the plan,
+// starting from the first one at start. The time between two points is the
+// distance between the waypoints at the speed of the config.
+func Fly(plan []generated.PlanWaypointResponse, start time.Time, cfg Config) Flight {
+	flight := Flight{
+		Points: make([]generated.TelemetryPointRequest, 0, len(plan)),
+	}
+	if len(plan) == 0 {
+		return flight
+	}
+
+	drain := cfg.Faults.BatteryDrain
+	if drain <= 0 {
+		drain = 1
+	}
+
+	abortAt := math.Inf(1)
+	if cfg.Faults.AbortAfter > 0 {
+		abortAt = cfg.Faults.AbortAfter * planDistance(plan)
+	}
+
+	random := rand.New(rand.NewSource(cfg.Seed))
+	neighbours := planNeighbours(plan)
+
+	battery, flown := 100.0, 0.0
+	for i, wp := range plan {
+		if i > 0 {
+			step := waypointDistance(plan[i-1], wp)
+			// The same plot at the same altitude is no new point
+			if step == 0 {
+				continue
+			}
+
+			flown += step
+			battery = math.Max(battery-step*cfg.BatteryPerMeter*drain, 0)
+		}
+
+		x, y := wp.X, wp.Y
+		if plots := neighbours[[2]int{x, y}]; len(plots) > 0 && random.Float64() < cfg.Faults.GPSDrift {
+			drifted := plots[random.Intn(len(plots))]
+			x, y = drifted[0], drifted[1]
+		}
+
+		flight.Points = append(flight.Points, generated.TelemetryPointRequest{
+			Timestamp: start.Add(time.Duration(flown / cfg.Speed * float64(time.Second))),
+			X:         x,
+			Y:         y,
+			Altitude:  float64(wp.Altitude),
+			Battery:   battery,
+		})
+
+		if i == len(plan)-1 {
+			break
+		}
+
+		if battery == 0 {
+			flight.AbortReason = AbortReasonBattery
+			break
+		}
+
+		if flown >= abortAt {
+			flight.AbortReason = AbortReasonInjected
+			break
+		}
+	}
+
+	return flight
+}
+
+func waypointDistance(from, to generated.PlanWaypointResponse) float64 {
+	horizontal := plotDistance * (math.Abs(float64(to.X-from.X)) + math.Abs(float64(to.Y-from.Y)))
+	return horizontal + math.Abs(float64(to.Altitude-from.Altitude))
+}
+
+func planDistance(plan []generated.PlanWaypointResponse) (distance float64) {
+	for i := 1; i < len(plan); i++ {
+		distance += waypointDistance(plan[i-1], plan[i])
+	}
+
+	return distance
+}
+
+// planNeighbours lists the plots of the plan next to every plot of the plan.
+// Drifting only onto them keeps every point on a plot the API accepts.
+func planNeighbours(plan []generated.PlanWaypointResponse) map[[2]int][][2]int {
+	plots := make(map[[2]int]bool, len(plan))
+	for _, wp := range plan {
+		plots[[2]int{wp.X, wp.Y}] = true
+	}
+
+	neighbours := make(map[[2]int][][2]int, len(plots))
+	for _, wp := range plan {
+		plot := [2]int{wp.X, wp.Y}
+		if _, ok := neighbours[plot]; ok {
+			continue
+		}
+
+		neighbours[plot] = [][2]int{}
+		for _, d := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			if next := [2]int{plot[0] + d[0], plot[1] + d[1]}; plots[next] {
+				neighbours[plot] = append(neighbours[plot], next)
+			}
+		}
+	}
+
+	return neighbours
+}
@@ -0,0 +1,70 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naufalfmm/plantation-drone-api/generated"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	flightStart = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	flightPlan  = []generated.PlanWaypointResponse{
+		{X: 1, Y: 1, Altitude: 1},
+		{X: 1, Y: 1, Altitude: 11},
+		{X: 2, Y: 1, Altitude: 6},
+		{X: 2, Y: 1, Altitude: 6},
+		{X: 3, Y: 1, Altitude: 6},
+		{X: 3, Y: 1, Altitude: 1},
+	}
+)
+
+func TestFly(t *testing.T) {
+	t.Run("Report every waypoint at the speed of the config", func(t *testing.T) {
+		flight := Fly(flightPlan, flightStart, Config{Speed: 5, BatteryPerMeter: 0.5})
+
+		assert.Equal(t, Flight{
+			Points: []generated.TelemetryPointRequest{
+				{Timestamp: flightStart, X: 1, Y: 1, Altitude: 1, Battery: 100},
+				{Timestamp: flightStart.Add(2 * time.Second), X: 1, Y: 1, Altitude: 11, Battery: 95},
+				{Timestamp: flightStart.Add(5 * time.Second), X: 2, Y: 1, Altitude: 6, Battery: 87.5},
+				{Timestamp: flightStart.Add(7 * time.Second), X: 3, Y: 1, Altitude: 6, Battery: 82.5},
+				{Timestamp: flightStart.Add(8 * time.Second), X: 3, Y: 1, Altitude: 1, Battery: 80},
+			},
+		}, flight)
+	})
+
+	t.Run("Abort once the drained battery runs out", func(t *testing.T) {
+		flight := Fly(flightPlan, flightStart, Config{Speed: 5, BatteryPerMeter: 2, Faults: Faults{BatteryDrain: 2}})
+
+		assert.Equal(t, AbortReasonBattery, flight.AbortReason)
+		assert.Len(t, flight.Points, 3)
+		assert.Equal(t, float64(0), flight.Points[2].Battery)
+	})
+
+	t.Run("Abort after the share of the plan distance", func(t *testing.T) {
+		flight := Fly(flightPlan, flightStart, Config{Speed: 5, Faults: Faults{AbortAfter: 0.5}})
+
+		assert.Equal(t, AbortReasonInjected, flight.AbortReason)
+		assert.Len(t, flight.Points, 3)
+	})
+
+	t.Run("Drift onto the neighbouring plots of the plan only", func(t *testing.T) {
+		flight := Fly(flightPlan, flightStart, Config{Speed: 5, Faults: Faults{GPSDrift: 1}})
+
+		assert.Empty(t, flight.AbortReason)
+		for i, point := range flight.Points {
+			assert.Equal(t, 1, point.Y)
+			assert.Contains(t, []int{1, 2, 3}, point.X)
+			assert.NotEqual(t, []int{1, 1, 2, 3, 3}[i], point.X)
+		}
+	})
+
+	t.Run("Report nothing for an empty plan", func(t *testing.T) {
+		flight := Fly(nil, flightStart, DefaultConfig())
+
+		assert.Empty(t, flight.Points)
+		assert.Empty(t, flight.AbortReason)
+	})
+}
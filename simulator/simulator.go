@@ -0,0 +1,123 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	missionStatusScheduled  = "scheduled"
+	missionStatusInProgress = "in_progress"
+	missionStatusCompleted  = "completed"
+	missionStatusAborted    = "aborted"
+)
+
+var (
+	ErrMissionEnded      = errors.New("mission has already ended")
+	ErrMissionInProgress = errors.New("mission is already in progress")
+)
+
+type RunOptions struct {
+	// BatchSize is the number of points posted in one telemetry batch
+	BatchSize int
+
+	// Speedup plays the flight this many times faster than it is flown. Zero
+	// posts every batch right away.
+	Speedup float64
+}
+
+type Result struct {
+	Flight Flight
+
+	// Stored is the number of points the API stored, leaving out the ones it
+	// already had
+	Stored int
+
+	Status string
+}
+
+// Run flies the plan snapshot of a mission. It starts a scheduled mission,
+// posts the telemetry of the flight in batches and then completes the
+// mission, or aborts it when a fault ends the flight early. A mission already
+// in progress is refused, since flying it again from the first waypoint would
+// post the telemetry of the points already flown once more.
+func Run(ctx context.Context, client *Client, missionId string, cfg Config, opts RunOptions) (result Result, err error) {
+	mission, err := client.GetMission(ctx, missionId)
+	if err != nil {
+		return result, err
+	}
+
+	switch mission.Status {
+	case missionStatusScheduled:
+		if _, err := client.SetMissionStatus(ctx, missionId, missionStatusInProgress, nil); err != nil {
+			return result, err
+		}
+	case missionStatusInProgress:
+		return result, ErrMissionInProgress
+	default:
+		return result, ErrMissionEnded
+	}
+
+	snapshot, err := client.GetSnapshot(ctx, mission.EstateId, mission.SnapshotVersion)
+	if err != nil {
+		return result, err
+	}
+
+	start := time.Now()
+	result.Flight = Fly(snapshot.Waypoints, start, cfg)
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+
+	points := result.Flight.Points
+	for len(points) > 0 {
+		batch := points[:min(batchSize, len(points))]
+		points = points[len(batch):]
+
+		// A batch is posted once its last point has been flown
+		if opts.Speedup > 0 {
+			flown := batch[len(batch)-1].Timestamp.Sub(start)
+			if err := sleep(ctx, time.Until(start.Add(time.Duration(float64(flown)/opts.Speedup)))); err != nil {
+				return result, err
+			}
+		}
+
+		stored, err := client.PostTelemetry(ctx, missionId, batch)
+		if err != nil {
+			return result, err
+		}
+
+		result.Stored += stored.Stored
+	}
+
+	result.Status = missionStatusCompleted
+	var reason *string
+	if result.Flight.AbortReason != "" {
+		result.Status, reason = missionStatusAborted, &result.Flight.AbortReason
+	}
+
+	if _, err := client.SetMissionStatus(ctx, missionId, result.Status, reason); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
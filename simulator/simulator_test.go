@@ -0,0 +1,124 @@
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/naufalfmm/plantation-drone-api/generated"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeApi answers like the API does for a single mission and keeps what the
+// simulator sent to it
+type fakeApi struct {
+	mission  generated.MissionResponse
+	statuses []generated.MissionStatusRequest
+	batches  [][]generated.TelemetryPointRequest
+}
+
+func (a *fakeApi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method + " " + r.URL.Path {
+	case "GET /mission/mission-1":
+		json.NewEncoder(w).Encode(a.mission)
+	case "GET /estate/estate-1/drone-plan:snapshot":
+		if r.URL.Query().Get("version") != "2" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(generated.ErrorResponse{Message: "snapshot is not found"})
+			return
+		}
+
+		json.NewEncoder(w).Encode(generated.DronePlanSnapshotResponse{Version: 2, Waypoints: flightPlan})
+	case "PUT /mission/mission-1/status":
+		var req generated.MissionStatusRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		a.statuses = append(a.statuses, req)
+		a.mission.Status = req.Status
+		json.NewEncoder(w).Encode(a.mission)
+	case "POST /mission/mission-1/telemetry":
+		var req generated.TelemetryBatchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		a.batches = append(a.batches, req.Points)
+		json.NewEncoder(w).Encode(generated.TelemetryBatchResponse{Received: len(req.Points), Stored: len(req.Points)})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(generated.ErrorResponse{Message: "mission is not found"})
+	}
+}
+
+func TestRun(t *testing.T) {
+	newApi := func(status string) (*fakeApi, *httptest.Server) {
+		api := &fakeApi{mission: generated.MissionResponse{Id: "mission-1", EstateId: "estate-1", SnapshotVersion: 2, Status: status}}
+		return api, httptest.NewServer(api)
+	}
+
+	t.Run("Start, fly and complete a scheduled mission", func(t *testing.T) {
+		api, server := newApi(missionStatusScheduled)
+		defer server.Close()
+
+		result, err := Run(context.Background(), NewClient(server.URL+"/"), "mission-1", Config{Speed: 5}, RunOptions{BatchSize: 2})
+
+		assert.Nil(t, err)
+		assert.Equal(t, missionStatusCompleted, result.Status)
+		assert.Equal(t, 5, result.Stored)
+		assert.Equal(t, []generated.MissionStatusRequest{
+			{Status: missionStatusInProgress},
+			{Status: missionStatusCompleted},
+		}, api.statuses)
+		assert.Len(t, api.batches, 3)
+		assert.Len(t, api.batches[2], 1)
+	})
+
+	t.Run("Abort the mission when a fault ends the flight", func(t *testing.T) {
+		api, server := newApi(missionStatusScheduled)
+		defer server.Close()
+
+		result, err := Run(context.Background(), NewClient(server.URL), "mission-1", Config{Speed: 5, Faults: Faults{AbortAfter: 0.5}}, RunOptions{BatchSize: 10})
+
+		reason := AbortReasonInjected
+
+		assert.Nil(t, err)
+		assert.Equal(t, missionStatusAborted, result.Status)
+		assert.Equal(t, []generated.MissionStatusRequest{
+			{Status: missionStatusInProgress},
+			{Status: missionStatusAborted, Reason: &reason},
+		}, api.statuses)
+		assert.Len(t, api.batches, 1)
+	})
+
+	t.Run("Return error when the mission is already in progress", func(t *testing.T) {
+		api, server := newApi(missionStatusInProgress)
+		defer server.Close()
+
+		_, err := Run(context.Background(), NewClient(server.URL), "mission-1", Config{Speed: 5}, RunOptions{})
+
+		assert.Equal(t, ErrMissionInProgress, err)
+		assert.Empty(t, api.statuses)
+		assert.Empty(t, api.batches)
+	})
+
+	t.Run("Return error when the mission has ended", func(t *testing.T) {
+		api, server := newApi(missionStatusCompleted)
+		defer server.Close()
+
+		_, err := Run(context.Background(), NewClient(server.URL), "mission-1", Config{Speed: 5}, RunOptions{})
+
+		assert.Equal(t, ErrMissionEnded, err)
+		assert.Empty(t, api.statuses)
+	})
+
+	t.Run("Return the error message of the API", func(t *testing.T) {
+		_, server := newApi(missionStatusScheduled)
+		defer server.Close()
+
+		_, err := Run(context.Background(), NewClient(server.URL), "mission-2", Config{Speed: 5}, RunOptions{})
+
+		assert.EqualError(t, err, "GET /mission/mission-2: 404 Not Found: mission is not found")
+	})
+}